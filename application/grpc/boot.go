@@ -2,47 +2,421 @@ package grpc
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgxpool"
-	articlev1 "github.com/mahdimehrabi/m1-article-proto/gen/go/article/article"
+	logv1 "github.com/mahdimehrabi/m1-log-proto/gen/go/log/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"log"
+	"m1-article-service/application/grpc/gateway"
+	"m1-article-service/application/grpc/health"
+	"m1-article-service/application/grpc/interceptor"
 	"m1-article-service/application/grpc/server"
+	"m1-article-service/domain/entity/migration"
+	articleRepository "m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/cache"
+	"m1-article-service/domain/repository/article/instrumented"
+	"m1-article-service/domain/repository/article/memory"
 	"m1-article-service/domain/repository/article/pgx"
+	"m1-article-service/domain/repository/article/sqlite"
+	idempotencyRepository "m1-article-service/domain/repository/idempotency"
+	idempotencyMemory "m1-article-service/domain/repository/idempotency/memory"
+	idempotencyPgx "m1-article-service/domain/repository/idempotency/pgx"
+	idempotencySQLite "m1-article-service/domain/repository/idempotency/sqlite"
+	webhookRepository "m1-article-service/domain/repository/webhook"
+	webhookMemory "m1-article-service/domain/repository/webhook/memory"
+	webhookPgx "m1-article-service/domain/repository/webhook/pgx"
 	"m1-article-service/domain/service/article"
-	"m1-article-service/infrastructure/godotenv"
+	"m1-article-service/domain/service/idempotency"
+	"m1-article-service/domain/service/webhook"
+	articlev1 "m1-article-service/gen/go/article/v1"
+	cacheInfra "m1-article-service/infrastructure/cache"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
+	"m1-article-service/infrastructure/lifecycle"
+	loggerInfra "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/log/remote"
 	"m1-article-service/infrastructure/log/zerolog"
+	"m1-article-service/infrastructure/metrics"
+	"m1-article-service/infrastructure/migrator"
+	"m1-article-service/infrastructure/ratelimit"
+	"m1-article-service/infrastructure/relay"
+	"m1-article-service/infrastructure/tracing"
+	webhookInfra "m1-article-service/infrastructure/webhook"
 	"net"
+	"net/http"
+	"os"
 )
 
-func Boot() {
-	logger := zerolog.NewLogger()
-	env := godotenv.NewEnv()
-	env.Load()
+func Boot(ctx context.Context, cfg *config.Config) {
+	localLogger, err := zerolog.NewLogger(zerolog.Config{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+	}, loggerInfra.DefaultContextFields...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// the lifecycle manager logs locally since the log shipper is itself
+	// drained during shutdown
+	lc := lifecycle.NewManager(localLogger)
+	var logger loggerInfra.Logger = localLogger
+
+	if cfg.LogServiceAddr != "" {
+		logConn, err := grpc.NewClient(cfg.LogServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatal(err)
+		}
+		remoteLogger := remote.NewLogger(logv1.NewLogServiceClient(logConn), localLogger, remote.Config{
+			Level:      cfg.LogLevel,
+			BufferSize: cfg.LogShipBufferSize,
+			MaxRetries: 3,
+			Policy:     cfg.LogShipPolicy,
+		}, loggerInfra.DefaultContextFields...)
+		logger = remoteLogger
+		lc.Go("log shipper", remoteLogger.Run)
+		lc.OnClose("log service connection", func(ctx context.Context) error {
+			return logConn.Close()
+		})
+	}
 
-	conn, err := pgxpool.New(context.Background(), env.DATABASE_HOST)
+	tp, err := tracing.NewProvider(context.Background(), tracing.Config{
+		ServiceName:  "m1-article-service",
+		Exporter:     cfg.TracingExporter,
+		FilePath:     cfg.TracingFile,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
+	lc.OnClose("tracer provider", tp.Shutdown)
+
+	checker := health.NewChecker(logger, cfg.HealthCheckInterval, articlev1.ArticleService_ServiceDesc.ServiceName)
+	checker.AddCheck("workers", lc.CheckWorkers)
+	m := metrics.New()
 
-	articleRepo := pgx.NewArticleRepository(env, conn)
+	store, err := openStorage(cfg, logger, lc, checker, m)
 	if err != nil {
 		log.Fatal(err)
 	}
+	articleRepo := store.articles
+	var sink relay.Sink = relay.Discard
+	switch cfg.OutboxSink {
+	case "log":
+		sink = relay.NewLogSink(logger)
+	case "stdout":
+		sink = relay.NewWriterSink(os.Stdout)
+	}
+	var webhookService *webhook.Service
+	if store.webhooks != nil {
+		dispatcher := webhookInfra.NewDispatcher(store.webhooks, logger, webhookInfra.Config{
+			Concurrency:  cfg.WebhookConcurrency,
+			Timeout:      cfg.WebhookTimeout,
+			MaxAttempts:  cfg.WebhookMaxAttempts,
+			Backoff:      cfg.WebhookBackoff,
+			MaxBackoff:   cfg.WebhookMaxBackoff,
+			AllowPrivate: cfg.WebhookAllowPrivate,
+		})
+		sink = relay.Fanout(sink, dispatcher)
+		lc.Go("webhook dispatcher", dispatcher.Run)
+		webhookService = webhook.NewService(logger, store.webhooks)
+	}
+	lc.Go("outbox relay", relay.New(store.outbox, sink, logger, relay.Config{
+		BatchSize:    cfg.OutboxBatchSize,
+		PollInterval: cfg.OutboxPollInterval,
+	}).Run)
+	switch cfg.CacheBackend {
+	case "memory":
+		articleRepo = cache.NewArticleRepository(articleRepo, cacheInfra.NewLRU(cfg.CacheSize), cfg.CacheTTL)
+	case "redis":
+		redis := cacheInfra.NewRedis(cacheInfra.RedisConfig{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+			Timeout:  cfg.RedisTimeout,
+		})
+		lc.OnClose("redis", func(context.Context) error { return redis.Close() })
+		articleRepo = cache.NewArticleRepository(articleRepo, redis, cfg.CacheTTL)
+	}
 	loggerService := article.NewService(logger, articleRepo)
+	var watchHub *article.Hub
+	if store.changes != nil {
+		watchHub = article.NewHub(store.changes, logger, article.HubConfig{
+			Buffer:    cfg.WatchBufferSize,
+			Retention: cfg.WatchRetention,
+		})
+		lc.Go("watch hub", watchHub.Run)
+	}
 
-	lis, err := net.Listen("tcp", env.ServerAddr)
+	lis, err := net.Listen("tcp", cfg.ServerAddr)
 	if err != nil {
 		log.Fatalf("failed to listen:%v", err)
 	}
-	var opts []grpc.ServerOption
+	// The REST gateway is a trusted proxy; when none is configured, it
+	// shares a secret with this server alone.
+	proxySecret := cfg.TrustedProxySecret
+	if proxySecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal(err)
+		}
+		proxySecret = hex.EncodeToString(secret)
+	}
+	// The outer recovery converts the panics of the other interceptors; the
+	// inner one, appended last, converts those of the handlers so that the
+	// access log and metrics see the codes.Internal they end with.
+	unary := []grpc.UnaryServerInterceptor{
+		interceptor.UnaryRecovery(logger),
+		interceptor.UnaryRequestContext(proxySecret),
+		interceptor.UnaryAccessLog(logger),
+		interceptor.UnaryMetrics(m),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		interceptor.StreamRecovery(logger),
+		interceptor.StreamRequestContext(proxySecret),
+		interceptor.StreamAccessLog(logger),
+		interceptor.StreamMetrics(m),
+	}
+	// The audit log exposes who changed what, and webhooks make the service
+	// call any URL, so only admins read the one and manage the other.
+	admins := cfg.AdminList()
+	if len(admins) == 0 {
+		logger.Warning(context.Background(), "ADMINS is empty, admin methods reject every call")
+	}
+	adminMethods := []string{
+		articlev1.ArticleService_ListAudit_FullMethodName,
+		"/" + articlev1.WebhookService_ServiceDesc.ServiceName + "/",
+	}
+	unary = append(unary, interceptor.UnaryAdmin(admins, adminMethods...))
+	stream = append(stream, interceptor.StreamAdmin(admins, adminMethods...))
+	if cfg.RateLimitBackend != "none" {
+		rules, err := ratelimit.ParseRules(cfg.RateLimitRules)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var limiter ratelimit.Limiter = ratelimit.NewMemory()
+		if cfg.RateLimitBackend == "postgres" {
+			pg := ratelimit.NewPostgres(store.pool, logger)
+			lc.Go("rate limit pruner", pg.Run)
+			limiter = pg
+		}
+		unary = append(unary, interceptor.UnaryRateLimit(limiter, rules, logger))
+		stream = append(stream, interceptor.StreamRateLimit(limiter, rules, logger))
+	}
+	unary = append(unary, interceptor.UnaryRecovery(logger))
+	stream = append(stream, interceptor.StreamRecovery(logger))
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
 	grpcServer := grpc.NewServer(opts...)
-	articleServer := server.NewArticleServer(logger, loggerService)
+	auditService := article.NewAuditService(logger, store.audit)
+	idempotencyService := idempotency.NewService(store.idempotency, logger, idempotency.Config{
+		TTL:   cfg.IdempotencyTTL,
+		Lease: cfg.IdempotencyLease,
+	})
+	lc.Go("idempotency key pruner", idempotencyService.Run)
+	articleServer := server.NewArticleServer(logger, loggerService, auditService, idempotencyService, watchHub)
 	articlev1.RegisterArticleServiceServer(grpcServer, articleServer)
+	if webhookService != nil {
+		articlev1.RegisterWebhookServiceServer(grpcServer, server.NewWebhookServer(logger, webhookService))
+	}
+
+	checker.Register(grpcServer)
 
 	reflection.Register(grpcServer)
-	logger.Info(fmt.Sprintf("running grpc server on: %s ⛴", env.ServerAddr))
-	err = grpcServer.Serve(lis)
-	log.Fatal(err)
+	lc.OnStop("grpc server", func(ctx context.Context) error {
+		return gracefulStop(ctx, grpcServer)
+	})
+	lc.OnStop("health", checker.Drain)
+	lc.Go("health checker", checker.Run)
+
+	serveErr := make(chan error, 3)
+	go func() {
+		logger.Info(context.Background(), fmt.Sprintf("running grpc server on: %s ⛴", cfg.ServerAddr))
+		serveErr <- grpcServer.Serve(lis)
+	}()
+
+	if cfg.GatewayAddr != "" {
+		gatewayServer, err := newGatewayServer(ctx, cfg, lis.Addr(), proxySecret, lc)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			logger.Info(context.Background(), fmt.Sprintf("serving REST gateway on: %s", cfg.GatewayAddr))
+			if err := gatewayServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- err
+			}
+		}()
+		lc.OnStop("gateway server", gatewayServer.Shutdown)
+	}
+
+	if cfg.MetricsAddr != "" {
+		metricsServer := m.NewServer(cfg.MetricsAddr)
+		go func() {
+			logger.Info(context.Background(), fmt.Sprintf("serving metrics on: %s/metrics", cfg.MetricsAddr))
+			if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- err
+			}
+		}()
+		lc.OnStop("metrics server", metricsServer.Shutdown)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := <-serveErr; err != nil {
+			logger.Error(context.Background(), err)
+		}
+		cancel()
+	}()
+	lc.Wait(ctx)
+
+	logger.Info(context.Background(), "shutting down gracefully")
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+	if err := lc.Shutdown(shutdownCtx); err != nil {
+		logger.Error(shutdownCtx, err)
+	}
+}
+
+// storage holds the repositories of the storage driver selected by
+// DATABASE_HOST; changes and webhooks are nil when the driver has no
+// change feed or webhook store, and pool unless the driver is Postgres.
+type storage struct {
+	pool     *pgxpool.Pool
+	articles articleRepository.Article
+	outbox   articleRepository.Outbox
+	changes  articleRepository.Changes
+	audit    articleRepository.AuditLog
+	webhooks webhookRepository.Webhook
+	// idempotency keeps the responses of requests by idempotency key.
+	idempotency idempotencyRepository.Store
+}
+
+// openStorage opens the selected storage driver and instruments its article
+// repository, registering health checks and shutdown hooks.
+func openStorage(cfg *config.Config, logger loggerInfra.Logger, lc *lifecycle.Manager, checker *health.Checker, m *metrics.Metrics) (storage, error) {
+	if cfg.DatabaseDriver() == config.DriverMemory {
+		logger.Warning(context.Background(), "storing articles in memory, they are lost on restart")
+		repo := memory.NewArticleRepository()
+		return storage{
+			articles:    instrumented.NewArticleRepository(repo, m),
+			outbox:      repo,
+			changes:     repo,
+			audit:       repo,
+			webhooks:    webhookMemory.NewWebhookRepository(),
+			idempotency: idempotencyMemory.NewStore(),
+		}, nil
+	}
+	if cfg.DatabaseDriver() == config.DriverSQLite {
+		return openSQLiteStorage(cfg, logger, lc, checker, m)
+	}
+
+	conn, err := database.NewPool(context.Background(), cfg, logger)
+	if err != nil {
+		return storage{}, err
+	}
+	lc.OnClose("postgres pool", func(ctx context.Context) error {
+		conn.Close()
+		return nil
+	})
+	if err := migrateSchema(cfg, logger); err != nil {
+		return storage{}, err
+	}
+	schemaVersion, err := migration.LatestVersion()
+	if err != nil {
+		return storage{}, err
+	}
+	checker.AddCheck("postgres", health.PostgresCheck(conn))
+	checker.AddCheck("migrations", health.MigrationCheck(conn, schemaVersion))
+	m.RegisterPool(conn)
+	return storage{
+		pool:        conn,
+		articles:    instrumented.NewArticleRepository(pgx.NewArticleRepository(cfg, conn), m),
+		outbox:      pgx.NewOutbox(conn),
+		changes:     pgx.NewChanges(conn),
+		audit:       pgx.NewAuditLog(conn),
+		webhooks:    webhookPgx.NewWebhookRepository(conn),
+		idempotency: idempotencyPgx.NewStore(conn),
+	}, nil
+}
+
+func openSQLiteStorage(cfg *config.Config, logger loggerInfra.Logger, lc *lifecycle.Manager, checker *health.Checker, m *metrics.Metrics) (storage, error) {
+	db, err := database.OpenSQLite(context.Background(), cfg)
+	if err != nil {
+		return storage{}, err
+	}
+	lc.OnClose("sqlite database", func(ctx context.Context) error {
+		return db.Close()
+	})
+	if err := migrateSchema(cfg, logger); err != nil {
+		return storage{}, err
+	}
+	schemaVersion, err := migration.Latest(migration.SQLiteFS)
+	if err != nil {
+		return storage{}, err
+	}
+	checker.AddCheck("sqlite", health.SQLiteCheck(db))
+	checker.AddCheck("migrations", health.SQLiteMigrationCheck(db, schemaVersion))
+	return storage{
+		articles:    instrumented.NewArticleRepository(sqlite.NewArticleRepository(db), m),
+		outbox:      sqlite.NewOutbox(db),
+		audit:       sqlite.NewAuditLog(db),
+		idempotency: idempotencySQLite.NewStore(db),
+	}, nil
+}
+
+// newGatewayServer returns the server of the REST gateway, which calls the
+// gRPC server listening on grpcAddr like any other client.
+func newGatewayServer(ctx context.Context, cfg *config.Config, grpcAddr net.Addr, proxySecret string, lc *lifecycle.Manager) (*http.Server, error) {
+	conn, err := grpc.NewClient(grpcAddr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	lc.OnClose("gateway connection", func(context.Context) error { return conn.Close() })
+	handler, err := gateway.NewHandler(ctx, conn, proxySecret)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Server{Addr: cfg.GatewayAddr, Handler: handler}, nil
+}
+
+// gracefulStop waits for in-flight RPCs to finish and forcefully closes
+// the remaining connections once ctx expires.
+func gracefulStop(ctx context.Context, s *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return fmt.Errorf("graceful stop: %w", ctx.Err())
+	}
+}
+
+// migrateSchema applies pending migrations when enabled and refuses to
+// start on a dirty schema or one newer than the binary.
+func migrateSchema(cfg *config.Config, logger loggerInfra.Logger) error {
+	m, err := migrator.New(cfg.DatabaseURL(), cfg.MigrateLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if cfg.MigrateOnStartup {
+		logger.Info(context.Background(), "applying database migrations")
+		if err := m.Up(); err != nil {
+			return fmt.Errorf("applying migrations: %w", err)
+		}
+	}
+	return m.Check()
 }
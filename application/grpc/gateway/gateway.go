@@ -0,0 +1,107 @@
+// Package gateway serves the REST/JSON API of the article service, relaying
+// every request to the gRPC server so that it goes through the same
+// interceptors.
+package gateway
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"m1-article-service/application/grpc/interceptor"
+	"m1-article-service/application/grpc/server"
+	articlev1 "m1-article-service/gen/go/article/v1"
+	"m1-article-service/gen/openapiv2"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/requestid"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// OpenAPIPath serves the OpenAPI document of the REST routes.
+const OpenAPIPath = "/openapi.json"
+
+// incomingHeaders are the HTTP request headers passed on as metadata under
+// the same name; the others follow the gateway defaults, except
+// proxyHeaders.
+var incomingHeaders = []string{
+	requestid.Header,
+	interceptor.APIKeyHeader,
+	server.IdempotencyKeyHeader,
+}
+
+// proxyHeaders are the metadata only the gateway itself sets, which REST
+// clients cannot pass with the Grpc-Metadata- prefix: the principal they
+// cannot assert, and the proxy secret and client address the gRPC server
+// trusts from the gateway.
+var proxyHeaders = []string{
+	principal.Header,
+	interceptor.ProxySecretHeader,
+	interceptor.ForwardedForHeader,
+}
+
+// outgoingHeaders are the response metadata passed on as HTTP headers under
+// the same name instead of with the Grpc-Metadata- prefix.
+var outgoingHeaders = []string{
+	requestid.Header,
+	interceptor.RetryAfterHeader,
+	server.IdempotentReplayedHeader,
+}
+
+// NewHandler returns the handler of the REST routes declared in the proto
+// files, calling the gRPC server on conn with proxySecret, so that the
+// server trusts the client address it forwards. Errors are written as the
+// JSON encoded gRPC status with the HTTP status matching its code.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn, proxySecret string) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithRoutingErrorHandler(routingError),
+		runtime.WithMetadata(func(context.Context, *http.Request) metadata.MD {
+			return metadata.Pairs(interceptor.ProxySecretHeader, proxySecret)
+		}),
+	)
+	if err := articlev1.RegisterArticleServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	err := mux.HandlePath(http.MethodGet, OpenAPIPath, func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapiv2.Article)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// routingError answers a known path called with another method with 405
+// rather than the 501 of the gateway default.
+func routingError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	if httpStatus != http.StatusMethodNotAllowed {
+		runtime.DefaultRoutingErrorHandler(ctx, mux, marshaler, w, r, httpStatus)
+		return
+	}
+	err := status.Error(codes.Unimplemented, http.StatusText(httpStatus))
+	runtime.HTTPError(ctx, mux, marshaler, w, r, &runtime.HTTPStatusError{HTTPStatus: httpStatus, Err: err})
+}
+
+func incomingHeader(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if slices.Contains(incomingHeaders, key) {
+		return key, true
+	}
+	if slices.Contains(proxyHeaders, strings.TrimPrefix(key, strings.ToLower(runtime.MetadataHeaderPrefix))) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeader(key string) (string, bool) {
+	if slices.Contains(outgoingHeaders, key) {
+		return key, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,171 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	articlev1 "m1-article-service/gen/go/article/v1"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeArticleServer answers Detail for ID 1, remembers the metadata of
+// Create and the article of Update and rejects List as rate limited.
+type fakeArticleServer struct {
+	articlev1.UnimplementedArticleServiceServer
+	createMD metadata.MD
+	updated  *articlev1.Article
+}
+
+func (s *fakeArticleServer) Create(ctx context.Context, a *articlev1.Article) (*articlev1.ArticleCreateResponse, error) {
+	s.createMD, _ = metadata.FromIncomingContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+	return &articlev1.ArticleCreateResponse{ID: 7}, nil
+}
+
+func (s *fakeArticleServer) Update(_ context.Context, a *articlev1.Article) (*articlev1.ArticleUpdateResponse, error) {
+	s.updated = a
+	return &articlev1.ArticleUpdateResponse{}, nil
+}
+
+func (s *fakeArticleServer) Detail(_ context.Context, id *articlev1.ArticleID) (*articlev1.ArticleDetailResponse, error) {
+	if id.ID != 1 {
+		return nil, status.Errorf(codes.NotFound, "article not found")
+	}
+	return &articlev1.ArticleDetailResponse{Article: &articlev1.Article{ID: 1, Title: "title"}}, nil
+}
+
+func (s *fakeArticleServer) List(ctx context.Context, _ *articlev1.Pagination) (*articlev1.ArticleListResponse, error) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", "3"))
+	return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded")
+}
+
+func newHandler(t *testing.T, srv *fakeArticleServer) http.Handler {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	articlev1.RegisterArticleServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	h, err := NewHandler(context.Background(), conn, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestHandler_Routes(t *testing.T) {
+	h := newHandler(t, &fakeArticleServer{})
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"detail", http.MethodGet, "/articles/1", http.StatusOK, `"Title":"title"`},
+		{"not found", http.MethodGet, "/articles/2", http.StatusNotFound, `"code":5`},
+		{"method not allowed", http.MethodPatch, "/articles", http.StatusMethodNotAllowed, `"Method Not Allowed"`},
+		{"unimplemented", http.MethodDelete, "/articles/1", http.StatusNotImplemented, `"code":12`},
+		{"unknown route", http.MethodGet, "/unknown", http.StatusNotFound, `"code":5`},
+		{"openapi", http.MethodGet, OpenAPIPath, http.StatusOK, `"/articles/{ID}"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(test.method, test.path, nil))
+			if rec.Code != test.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, test.wantStatus)
+			}
+			if body := rec.Body.String(); !strings.Contains(body, test.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", body, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	h := newHandler(t, &fakeArticleServer{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles?Page=2", nil))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("Retry-After = %q, want 3", got)
+	}
+	var body struct {
+		Code    int
+		Message string
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != int(codes.ResourceExhausted) || body.Message != "rate limit exceeded" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestHandler_UpdateReplaces(t *testing.T) {
+	for _, method := range []string{http.MethodPatch, http.MethodPut} {
+		srv := &fakeArticleServer{}
+		h := newHandler(t, srv)
+
+		// A partial body clears the missing fields rather than keeping them.
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/articles/7", strings.NewReader(`{"Title":"new"}`)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s = %d %s", method, rec.Code, rec.Body)
+		}
+		if got := srv.updated; got.ID != 7 || got.Title != "new" || got.Slug != "" || len(got.Tags) != 0 {
+			t.Errorf("%s updated article = %v", method, got)
+		}
+	}
+}
+
+func TestHandler_Headers(t *testing.T) {
+	srv := &fakeArticleServer{}
+	h := newHandler(t, srv)
+	req := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"Title":"title","Slug":"slug"}`))
+	req.Header.Set("Idempotency-Key", "key")
+	req.Header.Set("X-Principal", "alice")
+	req.Header.Set("Grpc-Metadata-X-Principal", "alice")
+	req.Header.Set("Grpc-Metadata-X-Proxy-Secret", "guess")
+	req.Header.Set("Grpc-Metadata-X-Forwarded-For", "198.51.100.1")
+	req.Header.Set("X-Unknown", "dropped")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ID":"7"`) {
+		t.Fatalf("response = %d %s", rec.Code, rec.Body)
+	}
+	for key, want := range map[string]string{"idempotency-key": "key", "x-forwarded-for": "192.0.2.1", "x-proxy-secret": "secret"} {
+		if got := srv.createMD.Get(key); len(got) != 1 || got[0] != want {
+			t.Errorf("metadata %s = %v, want %s", key, got, want)
+		}
+	}
+	for _, key := range []string{"x-unknown", "x-principal"} {
+		if got := srv.createMD.Get(key); len(got) != 0 {
+			t.Errorf("metadata %s = %v, want none", key, got)
+		}
+	}
+	if got := rec.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Errorf("Idempotent-Replayed = %q, want true", got)
+	}
+}
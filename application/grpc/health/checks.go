@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const migrationQuery = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+var errNoMigration = errors.New("no migration has been applied")
+
+// PostgresCheck pings the database through the pool.
+func PostgresCheck(pool *pgxpool.Pool) Check {
+	return func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	}
+}
+
+// MigrationCheck verifies that the schema is clean and at the version the
+// binary was built for.
+func MigrationCheck(pool *pgxpool.Pool, expected uint) Check {
+	return func(ctx context.Context) error {
+		var (
+			version int64
+			dirty   bool
+		)
+		err := pool.QueryRow(ctx, migrationQuery).Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errNoMigration
+		} else if err != nil {
+			return err
+		}
+		return checkVersion(version, dirty, expected)
+	}
+}
+
+// SQLiteCheck pings the SQLite database.
+func SQLiteCheck(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// SQLiteMigrationCheck is MigrationCheck for a SQLite database.
+func SQLiteMigrationCheck(db *sql.DB, expected uint) Check {
+	return func(ctx context.Context) error {
+		var (
+			version int64
+			dirty   bool
+		)
+		err := db.QueryRowContext(ctx, migrationQuery).Scan(&version, &dirty)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errNoMigration
+		} else if err != nil {
+			return err
+		}
+		return checkVersion(version, dirty, expected)
+	}
+}
+
+func checkVersion(version int64, dirty bool, expected uint) error {
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty", version)
+	}
+	if uint(version) != expected {
+		return fmt.Errorf("schema version is %d, expected %d", version, expected)
+	}
+	return nil
+}
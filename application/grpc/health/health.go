@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	logger "m1-article-service/infrastructure/log"
+	"sync"
+	"time"
+)
+
+// Check reports whether a single dependency is healthy.
+type Check func(ctx context.Context) error
+
+const checkTimeout = 3 * time.Second
+
+// Checker serves grpc.health.v1 and keeps the status of every registered
+// dependency up to date. Each dependency is exposed as its own health
+// service name; the overall status ("") and the status of every service in
+// services are SERVING only when all dependencies are healthy.
+type Checker struct {
+	logger   logger.Logger
+	server   *health.Server
+	interval time.Duration
+	services []string
+
+	mu     sync.Mutex
+	names  []string
+	checks map[string]Check
+}
+
+func NewChecker(logger logger.Logger, interval time.Duration, services ...string) *Checker {
+	c := &Checker{
+		logger:   logger,
+		server:   health.NewServer(),
+		interval: interval,
+		services: append([]string{""}, services...),
+		checks:   make(map[string]Check),
+	}
+	c.setOverall(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// AddCheck registers a dependency check under name. Until the first check
+// round has completed the dependency is reported as NOT_SERVING.
+func (c *Checker) AddCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = append(c.names, name)
+	c.checks[name] = check
+	c.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Run checks all dependencies immediately and then every interval until ctx
+// is done.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll runs every check once and updates the served statuses.
+func (c *Checker) CheckAll(ctx context.Context) {
+	c.mu.Lock()
+	names := append([]string(nil), c.names...)
+	c.mu.Unlock()
+
+	healthy := true
+	for _, name := range names {
+		c.mu.Lock()
+		check := c.checks[name]
+		c.mu.Unlock()
+
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := check(checkCtx)
+		cancel()
+
+		st := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			healthy = false
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+			c.logger.Warning(ctx, "health check failed", "check", name, "error", err.Error())
+		}
+		c.server.SetServingStatus(name, st)
+	}
+
+	if healthy {
+		c.setOverall(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.setOverall(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Drain marks every service as NOT_SERVING permanently so the orchestrator
+// stops routing traffic while in-flight requests finish.
+func (c *Checker) Drain(ctx context.Context) error {
+	c.server.Shutdown()
+	return nil
+}
+
+func (c *Checker) setOverall(st healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, st)
+	}
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	infraMock "m1-article-service/mock/infrastructure"
+	"testing"
+	"time"
+)
+
+func status(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	res, err := c.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("check %q: %v", service, err)
+	}
+	return res.Status
+}
+
+func TestChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Warning(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	dbErr := errors.New("connection refused")
+	var failing bool
+	c := NewChecker(loggerMock, time.Second, "article.v1.ArticleService")
+	c.AddCheck("postgres", func(ctx context.Context) error {
+		if failing {
+			return dbErr
+		}
+		return nil
+	})
+
+	if st := status(t, c, ""); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("before first check: got %v, want NOT_SERVING", st)
+	}
+
+	c.CheckAll(context.Background())
+	for _, service := range []string{"", "postgres", "article.v1.ArticleService"} {
+		if st := status(t, c, service); st != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("%q: got %v, want SERVING", service, st)
+		}
+	}
+
+	failing = true
+	c.CheckAll(context.Background())
+	if st := status(t, c, "postgres"); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("postgres: got %v, want NOT_SERVING", st)
+	}
+	if st := status(t, c, ""); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("overall: got %v, want NOT_SERVING", st)
+	}
+
+	failing = false
+	_ = c.Drain(context.Background())
+	c.CheckAll(context.Background())
+	if st := status(t, c, ""); st != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("after drain: got %v, want NOT_SERVING", st)
+	}
+}
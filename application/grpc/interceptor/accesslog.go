@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/remoteaddr"
+	"time"
+)
+
+// UnaryAccessLog writes one log line per RPC with its method, duration,
+// status code and peer; the request ID is attached from the context. The
+// peer is the client address set by the request context interceptor, which
+// sees through the REST gateway, or else the transport peer.
+func UnaryAccessLog(logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logAccess(ctx, logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func StreamAccessLog(logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logAccess(ss.Context(), logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logAccess(ctx context.Context, logger logger.Logger, method string, start time.Time, err error) {
+	peerAddr := remoteaddr.FromContext(ctx)
+	if peerAddr == "" {
+		peerAddr = "unknown"
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			peerAddr = p.Addr.String()
+		}
+	}
+	logger.Info(ctx, "rpc finished",
+		"method", method,
+		"duration", time.Since(start),
+		"code", status.Code(err).String(),
+		"peer", peerAddr)
+}
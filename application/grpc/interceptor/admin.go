@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"m1-article-service/infrastructure/principal"
+	"slices"
+	"strings"
+)
+
+// UnaryAdmin restricts methods to the principals in admins: other callers
+// get PermissionDenied, or Unauthenticated when they have no principal.
+// Each of methods is a full method name such as /pkg.Service/Method, or a
+// service prefix such as /pkg.Service/ covering all of its methods. It must
+// follow UnaryRequestContext.
+func UnaryAdmin(admins []string, methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkAdmin(ctx, admins, methods, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func StreamAdmin(admins []string, methods ...string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkAdmin(ss.Context(), admins, methods, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkAdmin(ctx context.Context, admins, methods []string, method string) error {
+	restricted := slices.ContainsFunc(methods, func(m string) bool {
+		return m == method || strings.HasSuffix(m, "/") && strings.HasPrefix(method, m)
+	})
+	if !restricted {
+		return nil
+	}
+	p := principal.FromContext(ctx)
+	if p == "" {
+		return status.Errorf(codes.Unauthenticated, "%s requires a principal", method)
+	}
+	if !slices.Contains(admins, p) {
+		return status.Errorf(codes.PermissionDenied, "%s is not an admin, required by %s", p, method)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"m1-article-service/infrastructure/principal"
+	"testing"
+)
+
+func TestUnaryAdmin(t *testing.T) {
+	admin := UnaryAdmin([]string{"alice"}, "/article.v1.ArticleService/ListAudit", "/article.v1.WebhookService/")
+	webhooks := &grpc.UnaryServerInfo{FullMethod: "/article.v1.WebhookService/CreateWebhook"}
+	audit := &grpc.UnaryServerInfo{FullMethod: "/article.v1.ArticleService/ListAudit"}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		info *grpc.UnaryServerInfo
+		want codes.Code
+	}{
+		{"admin", principal.NewContext(context.Background(), "alice"), webhooks, codes.OK},
+		{"other principal", principal.NewContext(context.Background(), "bob"), webhooks, codes.PermissionDenied},
+		{"no principal", context.Background(), webhooks, codes.Unauthenticated},
+		{"admin method", principal.NewContext(context.Background(), "alice"), audit, codes.OK},
+		{"admin method, other principal", principal.NewContext(context.Background(), "bob"), audit, codes.PermissionDenied},
+		{"admin method, no principal", context.Background(), audit, codes.Unauthenticated},
+		{"other method", context.Background(), info, codes.OK},
+	}
+	for _, tt := range tests {
+		_, err := admin(tt.ctx, nil, tt.info, func(ctx context.Context, req any) (any, error) { return nil, nil })
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package interceptor
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"m1-article-service/infrastructure/metrics"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/remoteaddr"
+	"m1-article-service/infrastructure/requestid"
+	infraMock "m1-article-service/mock/infrastructure"
+	"net"
+	"strings"
+	"testing"
+)
+
+var info = &grpc.UnaryServerInfo{FullMethod: "/article.v1.ArticleService/Detail"}
+
+func TestUnaryRecovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), "method", info.FullMethod, "stack", gomock.Any()).
+		Do(func(ctx context.Context, err error, fields ...any) {
+			if !strings.Contains(err.Error(), "boom") {
+				t.Errorf("panic log should contain the panic value, got %q", err)
+			}
+			if stack := fields[3].(string); !strings.Contains(stack, "goroutine") {
+				t.Errorf("panic log should contain the stack, got %q", stack)
+			}
+		})
+
+	_, err := UnaryRecovery(loggerMock)(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("got code %v, want Internal", status.Code(err))
+	}
+}
+
+// chain calls interceptors in order around handler, as
+// grpc.ChainUnaryInterceptor does.
+func chain(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// withRequestID matches the contexts carrying a request ID.
+type withRequestID struct{}
+
+func (withRequestID) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && requestid.FromContext(ctx) != ""
+}
+
+func (withRequestID) String() string { return "has a request ID" }
+
+func TestUnaryChain_HandlerPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	m := metrics.New()
+	hasRequestID := withRequestID{}
+	gomock.InOrder(
+		loggerMock.EXPECT().Error(hasRequestID, gomock.Any(), "method", info.FullMethod, "stack", gomock.Any()),
+		loggerMock.EXPECT().Info(hasRequestID, "rpc finished", "method", info.FullMethod,
+			"duration", gomock.Any(), "code", codes.Internal.String(), "peer", gomock.Any()),
+	)
+
+	// The order of boot.go.
+	unary := chain(
+		UnaryRecovery(loggerMock),
+		UnaryRequestContext(""),
+		UnaryAccessLog(loggerMock),
+		UnaryMetrics(m),
+		UnaryRecovery(loggerMock),
+	)
+	_, err := unary(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("got code %v, want Internal", status.Code(err))
+	}
+	if v := testutil.ToFloat64(m.RPCRequests.WithLabelValues(info.FullMethod, codes.Internal.String())); v != 1 {
+		t.Errorf("internal RPCs = %v, want 1", v)
+	}
+}
+
+func TestUnaryRequestContext(t *testing.T) {
+	var tests = []struct {
+		name     string
+		incoming metadata.MD
+		check    func(id string) bool
+	}{
+		{
+			name:     "propagated",
+			incoming: metadata.Pairs(requestid.Header, "abc"),
+			check:    func(id string) bool { return id == "abc" },
+		},
+		{
+			name:     "generated",
+			incoming: metadata.MD{},
+			check:    func(id string) bool { return len(id) == 32 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), test.incoming)
+			_, err := UnaryRequestContext("")(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				if id := requestid.FromContext(ctx); !test.check(id) {
+					t.Errorf("unexpected request id %q", id)
+				}
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUnaryRequestContext_TrustedProxy(t *testing.T) {
+	tests := []struct {
+		name          string
+		proxySecret   string
+		sent          string
+		wantPrincipal string
+		wantAddr      string
+	}{
+		{"trusted proxy", "secret", "secret", "alice", "203.0.113.9"},
+		{"wrong secret", "secret", "guess", "", "127.0.0.1:51234"},
+		{"no secret", "secret", "", "", "127.0.0.1:51234"},
+		{"no trusted proxy", "", "", "", "127.0.0.1:51234"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			md := metadata.Pairs(principal.Header, "alice", ForwardedForHeader, "198.51.100.1, 203.0.113.9")
+			if test.sent != "" {
+				md.Set(ProxySecretHeader, test.sent)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			// a sidecar on the loopback interface is not trusted for being there
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 51234}})
+			_, err := UnaryRequestContext(test.proxySecret)(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				if p := principal.FromContext(ctx); p != test.wantPrincipal {
+					t.Errorf("principal = %q, want %q", p, test.wantPrincipal)
+				}
+				if addr := remoteaddr.FromContext(ctx); addr != test.wantAddr {
+					t.Errorf("client address = %q, want %q", addr, test.wantAddr)
+				}
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/ratelimit"
+	"m1-article-service/infrastructure/remoteaddr"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// APIKeyHeader identifies clients calling without a principal.
+	APIKeyHeader = "x-api-key"
+	// RetryAfterHeader is set on rate limited responses to the number of
+	// seconds after which the request may succeed.
+	RetryAfterHeader = "retry-after"
+)
+
+// UnaryRateLimit rejects the requests of a client over the limit of the
+// rule matching the method with ResourceExhausted. Clients are told apart
+// by principal, else by peer IP and API key. The gRPC infrastructure
+// services, such as health checks, are not limited, and requests are let
+// through when the limiter fails.
+func UnaryRateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules, logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if header, err := checkRate(ctx, limiter, rules, logger, info.FullMethod); err != nil {
+			_ = grpc.SetHeader(ctx, header)
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func StreamRateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules, logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if header, err := checkRate(ss.Context(), limiter, rules, logger, info.FullMethod); err != nil {
+			_ = ss.SetHeader(header)
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// checkRate returns the error and response header of a rate limited
+// request.
+func checkRate(ctx context.Context, limiter ratelimit.Limiter, rules ratelimit.Rules, logger logger.Logger, method string) (metadata.MD, error) {
+	if strings.HasPrefix(method, "/grpc.") {
+		return nil, nil
+	}
+	rule, ok := rules.For(method)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		allowed    = true
+		retryAfter time.Duration
+		err        error
+	)
+	for _, key := range clientKeys(ctx) {
+		if allowed, retryAfter, err = limiter.Allow(ctx, key+" "+rule.Pattern, rule.Limit); err != nil {
+			logger.Error(ctx, fmt.Errorf("rate limiter: %w", err), "method", method)
+			return nil, nil
+		}
+		if !allowed {
+			break
+		}
+	}
+	if allowed {
+		return nil, nil
+	}
+	secs := int(math.Ceil(retryAfter.Seconds()))
+	header := metadata.Pairs(RetryAfterHeader, strconv.Itoa(secs))
+	return header, status.Errorf(codes.ResourceExhausted, "rate limit of %s exceeded, retry in %v", rule.Pattern, time.Duration(secs)*time.Second)
+}
+
+// clientKeys returns the limiter keys a request counts against, all of
+// which must allow it. The principal is authenticated by the API gateway,
+// but API keys are not verified: they are only limited within the limit of
+// the peer IP, so that rotating them neither lifts the limit nor adds more
+// buckets than the IP is allowed requests. API keys are hashed so that
+// they are not stored by the limiter.
+func clientKeys(ctx context.Context) []string {
+	if p := principal.FromContext(ctx); p != "" {
+		return []string{"principal:" + p}
+	}
+	addr := remoteaddr.FromContext(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	keys := []string{"ip:" + addr}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if key := first(md, APIKeyHeader); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		keys = append(keys, "key:"+hex.EncodeToString(sum[:16]))
+	}
+	return keys
+}
@@ -0,0 +1,121 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/ratelimit"
+	"m1-article-service/infrastructure/remoteaddr"
+	infraMock "m1-article-service/mock/infrastructure"
+	"slices"
+	"testing"
+	"time"
+)
+
+// headerStream records the headers set by a unary interceptor.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, ratelimit.Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("database down")
+}
+
+func TestUnaryRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rules, _ := ratelimit.ParseRules("Detail=2/1m")
+	limit := UnaryRateLimit(ratelimit.NewMemory(), rules, infraMock.NewMockLog(ctrl))
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	call := func(ctx context.Context, method string) (metadata.MD, error) {
+		stream := &headerStream{}
+		ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+		_, err := limit(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, ok)
+		return stream.header, err
+	}
+
+	alice := principal.NewContext(context.Background(), "alice")
+	for i := 0; i < 2; i++ {
+		if _, err := call(alice, info.FullMethod); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	header, err := call(alice, info.FullMethod)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("request over the limit: %v, want ResourceExhausted", err)
+	}
+	if got := header.Get(RetryAfterHeader); len(got) != 1 || got[0] != "30" {
+		t.Errorf("%s = %v, want 30", RetryAfterHeader, got)
+	}
+
+	if _, err := call(alice, "/article.v1.ArticleService/List"); err != nil {
+		t.Errorf("method without rule: %v", err)
+	}
+	if _, err := call(principal.NewContext(context.Background(), "bob"), info.FullMethod); err != nil {
+		t.Errorf("another principal: %v", err)
+	}
+}
+
+func TestClientKeys(t *testing.T) {
+	peer := remoteaddr.NewContext(context.Background(), "10.0.0.7:51234")
+	withKey := metadata.NewIncomingContext(peer, metadata.Pairs(APIKeyHeader, "secret"))
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"principal", principal.NewContext(withKey, "alice"), []string{"principal:alice"}},
+		{"api key", withKey, []string{"ip:10.0.0.7", "key:2bb80d537b1da3e38bd30361aa855686"}},
+		{"peer", peer, []string{"ip:10.0.0.7"}},
+	}
+	for _, tt := range tests {
+		if got := clientKeys(tt.ctx); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: clientKeys = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnaryRateLimit_RotatedAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rules, _ := ratelimit.ParseRules("Detail=2/1m")
+	limit := UnaryRateLimit(ratelimit.NewMemory(), rules, infraMock.NewMockLog(ctrl))
+	peer := remoteaddr.NewContext(context.Background(), "10.0.0.7:51234")
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		ctx := metadata.NewIncomingContext(peer, metadata.Pairs(APIKeyHeader, fmt.Sprint("key-", i)))
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &headerStream{})
+		_, err = limit(ctx, nil, info, func(ctx context.Context, req any) (any, error) { return nil, nil })
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("third key from the same IP: %v, want ResourceExhausted", err)
+	}
+}
+
+func TestUnaryRateLimit_LimiterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), "method", info.FullMethod)
+	rules, _ := ratelimit.ParseRules("*=1/1m")
+
+	called := false
+	_, err := UnaryRateLimit(failingLimiter{}, rules, loggerMock)(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if err != nil || !called {
+		t.Errorf("request was not let through: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	logger "m1-article-service/infrastructure/log"
+	"runtime/debug"
+)
+
+// UnaryRecovery converts a panic in a handler into codes.Internal and logs
+// the panic value together with its stack trace.
+func UnaryRecovery(logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func StreamRecovery(logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, logger logger.Logger, method string, r any) error {
+	logger.Error(ctx, fmt.Errorf("panic: %v", r), "method", method, "stack", string(debug.Stack()))
+	return status.Errorf(codes.Internal, "internal error")
+}
@@ -0,0 +1,102 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/remoteaddr"
+	"m1-article-service/infrastructure/requestid"
+	"strings"
+)
+
+const maxRequestIDLength = 128
+
+const (
+	// ForwardedForHeader lists the client addresses of a request relayed by
+	// a proxy, the last one being the address the proxy was called from.
+	ForwardedForHeader = "x-forwarded-for"
+	// ProxySecretHeader carries the secret shared with the trusted proxies.
+	ProxySecretHeader = "x-proxy-secret"
+)
+
+// UnaryRequestContext copies request scoped values from the incoming
+// metadata into the context: the x-request-id header (generated when
+// missing and echoed back as a response header), the x-principal header and
+// the address of the client.
+//
+// x-principal and x-forwarded-for are only honoured when the request
+// carries proxySecret in x-proxy-secret, as do the API gateway, which
+// authenticates callers and overwrites x-principal, and the REST gateway,
+// which never forwards it. Otherwise they are ignored: the caller has no
+// principal and its address is the peer address. Nothing is trusted when
+// proxySecret is empty.
+func UnaryRequestContext(proxySecret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withRequestContext(ctx, proxySecret), req)
+	}
+}
+
+func StreamRequestContext(proxySecret string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: withRequestContext(ss.Context(), proxySecret)})
+	}
+}
+
+func withRequestContext(ctx context.Context, proxySecret string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := first(md, requestid.Header)
+	if id == "" || len(id) > maxRequestIDLength {
+		id = requestid.New()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.Header, id))
+	ctx = requestid.NewContext(ctx, id)
+
+	trusted := proxySecret != "" &&
+		subtle.ConstantTimeCompare([]byte(first(md, ProxySecretHeader)), []byte(proxySecret)) == 1
+	if p := first(md, principal.Header); p != "" && trusted {
+		ctx = principal.NewContext(ctx, p)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if trusted {
+			addr = forwardedFor(md, addr)
+		}
+		ctx = remoteaddr.NewContext(ctx, addr)
+	}
+	return ctx
+}
+
+// forwardedFor returns the last address of x-forwarded-for, or peerAddr
+// when there is none.
+func forwardedFor(md metadata.MD, peerAddr string) string {
+	forwarded := md.Get(ForwardedForHeader)
+	if len(forwarded) == 0 {
+		return peerAddr
+	}
+	addrs := strings.Split(forwarded[len(forwarded)-1], ",")
+	if addr := strings.TrimSpace(addrs[len(addrs)-1]); addr != "" {
+		return addr
+	}
+	return peerAddr
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// wrappedStream overrides the context of a server stream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
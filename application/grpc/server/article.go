@@ -2,34 +2,56 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"github.com/mahdimehrabi/m1-article-proto/gen/go/article/article"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"io"
 	"m1-article-service/domain/entity"
 	articleRepo "m1-article-service/domain/repository/article"
 	"m1-article-service/domain/service/article"
+	"m1-article-service/domain/service/idempotency"
+	articlev1 "m1-article-service/gen/go/article/v1"
 	logger "m1-article-service/infrastructure/log"
+	"time"
 )
 
 type ArticleServer struct {
 	logger         logger.Logger
 	articleService *article.Service
+	auditService   *article.AuditService
+	// idempotency is nil when Create ignores idempotency keys.
+	idempotency *idempotency.Service
+	// watchHub is nil when the storage has no change feed.
+	watchHub *article.Hub
 	articlev1.UnimplementedArticleServiceServer
 }
 
-func NewArticleServer(logger logger.Logger, articleService *article.Service) *ArticleServer {
-	return &ArticleServer{logger: logger, articleService: articleService}
+func NewArticleServer(logger logger.Logger, articleService *article.Service, auditService *article.AuditService,
+	idempotencyService *idempotency.Service, watchHub *article.Hub) *ArticleServer {
+	return &ArticleServer{logger: logger, articleService: articleService, auditService: auditService,
+		idempotency: idempotencyService, watchHub: watchHub}
 }
 
+// Create returns the response of the first request when retried with the
+// same idempotency key, see IdempotencyKeyHeader.
 func (a ArticleServer) Create(ctx context.Context, a2 *articlev1.Article) (*articlev1.ArticleCreateResponse, error) {
-	article := entity.NewArticle(a2.Title, a2.Slug, a2.Tags)
+	return idempotent(ctx, a.idempotency, a.logger, articlev1.ArticleService_Create_FullMethodName, a2,
+		&articlev1.ArticleCreateResponse{}, func() (*articlev1.ArticleCreateResponse, error) {
+			return a.create(ctx, a2)
+		})
+}
+
+func (a ArticleServer) create(ctx context.Context, a2 *articlev1.Article) (*articlev1.ArticleCreateResponse, error) {
+	article := fromProto(a2)
 	id, err := a.articleService.Create(ctx, article)
 
-	if errors.Is(err, articleRepo.ErrAlreadyExist) {
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if errors.Is(err, articleRepo.ErrAlreadyExist) {
 		return nil, status.Errorf(codes.AlreadyExists, "article with this title already exists")
 	} else if err != nil {
-		a.logger.Error(err)
+		a.logger.Error(ctx, err)
 		return nil, status.Errorf(codes.Internal, "internal error")
 	}
 
@@ -39,15 +61,19 @@ func (a ArticleServer) Create(ctx context.Context, a2 *articlev1.Article) (*arti
 }
 
 func (a ArticleServer) Update(ctx context.Context, a2 *articlev1.Article) (*articlev1.ArticleUpdateResponse, error) {
-	article := entity.NewArticle(a2.Title, a2.Slug, a2.Tags)
+	article := fromProto(a2)
 	article.ID = a2.ID
+	// An update without status keeps the stored one.
+	article.Status = a2.Status
 	err := a.articleService.Update(ctx, article)
-	if errors.Is(err, articleRepo.ErrAlreadyExist) {
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if errors.Is(err, articleRepo.ErrAlreadyExist) {
 		return nil, status.Errorf(codes.AlreadyExists, "article with this title already exists")
 	} else if errors.Is(err, articleRepo.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "article not found")
 	} else if err != nil {
-		a.logger.Error(err)
+		a.logger.Error(ctx, err)
 		return nil, status.Errorf(codes.Internal, "internal error")
 	}
 
@@ -59,7 +85,7 @@ func (a ArticleServer) Delete(ctx context.Context, id *articlev1.ArticleID) (*ar
 	if errors.Is(err, articleRepo.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "article not found")
 	} else if err != nil {
-		a.logger.Error(err)
+		a.logger.Error(ctx, err)
 		return nil, status.Errorf(codes.Internal, "internal error")
 	}
 	return &articlev1.Empty{}, nil
@@ -70,35 +96,286 @@ func (a ArticleServer) Detail(ctx context.Context, id *articlev1.ArticleID) (*ar
 	if errors.Is(err, articleRepo.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "article not found")
 	} else if err != nil {
-		a.logger.Error(err)
+		a.logger.Error(ctx, err)
 		return nil, status.Errorf(codes.Internal, "internal error")
 	}
 	return &articlev1.ArticleDetailResponse{
-		Article: &articlev1.Article{
-			ID:    article.ID,
-			Title: article.Title,
-			Tags:  article.Tags,
-			Slug:  article.Slug,
-		},
+		Article: toProto(article),
 	}, nil
 }
 
 func (a ArticleServer) List(ctx context.Context, pagination *articlev1.Pagination) (*articlev1.ArticleListResponse, error) {
 	articles, err := a.articleService.List(ctx, uint16(pagination.Page))
 	if err != nil {
-		a.logger.Error(err)
+		a.logger.Error(ctx, err)
 		return nil, status.Errorf(codes.Internal, "internal error")
 	}
 	articlesResObjs := make([]*articlev1.Article, len(articles))
 	for i, article := range articles {
-		articlesResObjs[i] = &articlev1.Article{
-			ID:    article.ID,
-			Title: article.Title,
-			Tags:  article.Tags,
-			Slug:  article.Slug,
-		}
+		articlesResObjs[i] = toProto(article)
 	}
 	return &articlev1.ArticleListResponse{
 		Article: articlesResObjs,
 	}, nil
 }
+
+func (a ArticleServer) Search(ctx context.Context, req *articlev1.SearchRequest) (*articlev1.ArticleListResponse, error) {
+	articles, err := a.articleService.Search(ctx, req.Query, uint16(req.Page))
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		a.logger.Error(ctx, err)
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+	res := make([]*articlev1.Article, len(articles))
+	for i, article := range articles {
+		res[i] = toProto(article)
+	}
+	return &articlev1.ArticleListResponse{Article: res}, nil
+}
+
+func (a ArticleServer) Import(stream articlev1.ArticleService_ImportServer) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return stream.SendAndClose(&articlev1.ImportResponse{})
+	} else if err != nil {
+		return err
+	}
+
+	opts := article.ImportOptions{ImportOptions: articleRepo.ImportOptions{
+		DryRun:       first.GetOptions().GetDryRun(),
+		UpsertBySlug: first.GetOptions().GetUpsertBySlug(),
+	}}
+	report, err := a.articleService.Import(ctx, &importStream{stream: stream, pending: first}, opts)
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return s.Err()
+		}
+		a.logger.Error(ctx, err)
+		return status.Errorf(codes.Internal, "internal error")
+	}
+
+	res := &articlev1.ImportResponse{
+		Created: uint64(report.Created),
+		Updated: uint64(report.Updated),
+		Failed:  uint64(report.Failed),
+		Errors:  make([]*articlev1.ImportRowError, len(report.Errors)),
+	}
+	for i, rowErr := range report.Errors {
+		res.Errors[i] = &articlev1.ImportRowError{Row: uint64(rowErr.Row), Message: rowErr.Err.Error()}
+	}
+	return stream.SendAndClose(res)
+}
+
+func (a ArticleServer) Export(req *articlev1.ExportRequest, stream articlev1.ArticleService_ExportServer) error {
+	ctx := stream.Context()
+	filter := articleRepo.ExportFilter{
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Tag:         req.Tag,
+		Status:      req.Status,
+	}
+	err := a.articleService.Export(ctx, filter, func(article *entity.Article) error {
+		return stream.Send(toProto(article))
+	})
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return s.Err()
+		}
+		return status.Errorf(codes.Internal, "internal error")
+	}
+	return nil
+}
+
+func (a ArticleServer) BatchGet(ctx context.Context, req *articlev1.BatchGetRequest) (*articlev1.BatchGetResponse, error) {
+	articles, err := a.articleService.BatchGet(ctx, req.IDs)
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		a.logger.Error(ctx, err)
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+	items := make([]*articlev1.BatchGetItem, len(articles))
+	for i, article := range articles {
+		items[i] = &articlev1.BatchGetItem{ID: req.IDs[i]}
+		if article != nil {
+			items[i].Found = true
+			items[i].Article = toProto(article)
+		}
+	}
+	return &articlev1.BatchGetResponse{Items: items}, nil
+}
+
+func (a ArticleServer) BatchCreate(ctx context.Context, req *articlev1.BatchCreateRequest) (*articlev1.BatchResponse, error) {
+	articles := make([]*entity.Article, len(req.Articles))
+	for i, a2 := range req.Articles {
+		articles[i] = fromProto(a2)
+	}
+	results, err := a.articleService.BatchCreate(ctx, articles)
+	return a.batchResponse(ctx, results, err)
+}
+
+func (a ArticleServer) BatchDelete(ctx context.Context, req *articlev1.BatchDeleteRequest) (*articlev1.BatchResponse, error) {
+	results, err := a.articleService.BatchDelete(ctx, req.IDs)
+	return a.batchResponse(ctx, results, err)
+}
+
+func (a ArticleServer) batchResponse(ctx context.Context, results []articleRepo.BatchResult, err error) (*articlev1.BatchResponse, error) {
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		a.logger.Error(ctx, err)
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+	res := &articlev1.BatchResponse{
+		Applied: articleRepo.BatchApplied(results),
+		Results: make([]*articlev1.BatchItemResult, len(results)),
+	}
+	for i, result := range results {
+		item := &articlev1.BatchItemResult{}
+		switch {
+		case result.Err == nil:
+			item.ID = result.ID
+		case errors.Is(result.Err, articleRepo.ErrBatchAborted):
+			item.Code, item.Message = uint32(codes.Aborted), "not applied, another item failed"
+		case errors.Is(result.Err, articleRepo.ErrValidation):
+			item.Code, item.Message = uint32(codes.InvalidArgument), result.Err.Error()
+		case errors.Is(result.Err, articleRepo.ErrNotFound):
+			item.Code, item.Message = uint32(codes.NotFound), "article not found"
+		case errors.Is(result.Err, articleRepo.ErrAlreadyExist):
+			item.Code, item.Message = uint32(codes.AlreadyExists), "article with this title already exists"
+		default:
+			a.logger.Error(ctx, result.Err)
+			item.Code, item.Message = uint32(codes.Internal), "internal error"
+		}
+		res.Results[i] = item
+	}
+	return res, nil
+}
+
+func (a ArticleServer) Watch(req *articlev1.WatchRequest, stream articlev1.ArticleService_WatchServer) error {
+	if a.watchHub == nil {
+		return status.Errorf(codes.Unimplemented, "watch is not supported by the configured storage")
+	}
+	ctx := stream.Context()
+	err := a.watchHub.Watch(ctx, req.AfterSequence, func(e *entity.Event) error {
+		return stream.Send(&articlev1.ArticleEvent{
+			Sequence:   e.ID,
+			Type:       e.Type,
+			ArticleID:  e.ArticleID,
+			Article:    toProto(e.Article),
+			OccurredAt: e.OccurredAt.UnixMilli(),
+		})
+	})
+	switch {
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	case errors.Is(err, article.ErrSubscriberTooSlow):
+		return status.Errorf(codes.ResourceExhausted, "watcher fell behind, resume after the last received sequence")
+	case errors.Is(err, article.ErrFeedUnavailable):
+		return status.Errorf(codes.Unavailable, "article events are unavailable, retry later")
+	case errors.Is(err, articleRepo.ErrPruned):
+		return status.Errorf(codes.OutOfRange, "events after sequence %d are no longer retained, resync and watch from zero", req.AfterSequence)
+	case err != nil:
+		if s, ok := status.FromError(err); ok {
+			return s.Err()
+		}
+		a.logger.Error(ctx, err)
+		return status.Errorf(codes.Internal, "internal error")
+	}
+	return nil
+}
+
+func (a ArticleServer) ListAudit(ctx context.Context, req *articlev1.ListAuditRequest) (*articlev1.ListAuditResponse, error) {
+	filter := articleRepo.AuditFilter{ArticleID: req.ArticleID, Principal: req.Principal}
+	if req.From != 0 {
+		filter.From = time.UnixMilli(req.From)
+	}
+	if req.To != 0 {
+		// inclusive of the whole last millisecond
+		filter.To = time.UnixMilli(req.To + 1).Add(-time.Nanosecond)
+	}
+	entries, err := a.auditService.Audit(ctx, filter, uint16(req.Page))
+	if errors.Is(err, articleRepo.ErrValidation) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		a.logger.Error(ctx, err)
+		return nil, status.Errorf(codes.Internal, "internal error")
+	}
+	res := &articlev1.ListAuditResponse{Entries: make([]*articlev1.AuditEntry, len(entries))}
+	for i, e := range entries {
+		entry := &articlev1.AuditEntry{
+			ID:         e.ID,
+			Action:     e.Action,
+			ArticleID:  e.ArticleID,
+			Principal:  e.Actor.Principal,
+			RequestID:  e.Actor.RequestID,
+			Peer:       e.Actor.Peer,
+			OccurredAt: e.OccurredAt.UnixMilli(),
+		}
+		if entry.Before, err = snapshotJSON(e.Before); err != nil {
+			a.logger.Error(ctx, err)
+			return nil, status.Errorf(codes.Internal, "internal error")
+		}
+		if entry.After, err = snapshotJSON(e.After); err != nil {
+			a.logger.Error(ctx, err)
+			return nil, status.Errorf(codes.Internal, "internal error")
+		}
+		res.Entries[i] = entry
+	}
+	return res, nil
+}
+
+// snapshotJSON encodes an audited article, nil being an empty string.
+func snapshotJSON(a *entity.Article) (string, error) {
+	if a == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(a)
+	return string(data), err
+}
+
+// fromProto returns a new article from a request, published unless another
+// status is given.
+func fromProto(a2 *articlev1.Article) *entity.Article {
+	article := entity.NewArticle(a2.Title, a2.Slug, a2.Tags)
+	if a2.Status != "" {
+		article.Status = a2.Status
+	}
+	return article
+}
+
+func toProto(article *entity.Article) *articlev1.Article {
+	return &articlev1.Article{
+		ID:        article.ID,
+		Title:     article.Title,
+		Tags:      article.Tags,
+		Slug:      article.Slug,
+		CreatedAt: article.CreatedAt,
+		Status:    article.Status,
+	}
+}
+
+// importStream adapts the client stream to article.ImportSource. Messages
+// carrying only options are skipped; pending holds the already read first one.
+type importStream struct {
+	stream  articlev1.ArticleService_ImportServer
+	pending *articlev1.ImportRequest
+}
+
+func (s *importStream) Next() (*entity.Article, error) {
+	for {
+		req := s.pending
+		s.pending = nil
+		if req == nil {
+			var err error
+			if req, err = s.stream.Recv(); err != nil {
+				return nil, err
+			}
+		}
+		if a := req.GetArticle(); a != nil {
+			return &entity.Article{ID: a.ID, Title: a.Title, Slug: a.Slug, Tags: a.Tags, CreatedAt: a.CreatedAt, Status: a.Status}, nil
+		}
+	}
+}
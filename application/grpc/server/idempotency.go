@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"m1-article-service/domain/service/idempotency"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/principal"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key that makes a
+	// retried request return the response of the first one.
+	IdempotencyKeyHeader = "idempotency-key"
+	// IdempotentReplayedHeader is set to "true" on replayed responses.
+	IdempotentReplayedHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotent runs handle at most once per idempotency key of the request,
+// returning the stored response into res when the request is retried. Keys
+// are scoped to the principal and the method, and a request is identified
+// by its deterministic encoding. Requests without a key, or a server
+// without an idempotency service, run handle directly.
+func idempotent[T proto.Message](ctx context.Context, service *idempotency.Service, log logger.Logger, method string,
+	req proto.Message, res T, handle func() (T, error)) (T, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(IdempotencyKeyHeader)
+	if service == nil || len(keys) == 0 {
+		return handle()
+	}
+	var zero T
+	key := keys[0]
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return zero, status.Errorf(codes.InvalidArgument, "%s must have 1 to %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		log.Error(ctx, err)
+		return zero, status.Errorf(codes.Internal, "internal error")
+	}
+
+	var handled T
+	response, replayed, err := service.Do(ctx, hash(principal.FromContext(ctx), method, key), hash(string(encoded)),
+		func(context.Context) ([]byte, error) {
+			var err error
+			if handled, err = handle(); err != nil {
+				return nil, err
+			}
+			return proto.Marshal(handled)
+		})
+	switch {
+	case errors.Is(err, idempotency.ErrKeyReused):
+		return zero, status.Errorf(codes.InvalidArgument, "%s was used for a different request", IdempotencyKeyHeader)
+	case errors.Is(err, idempotency.ErrInProgress):
+		return zero, status.Errorf(codes.Aborted, "a request with this %s is in progress", IdempotencyKeyHeader)
+	case err != nil:
+		if _, ok := status.FromError(err); !ok {
+			log.Error(ctx, err)
+			return zero, status.Errorf(codes.Internal, "internal error")
+		}
+		return zero, err
+	}
+	if !replayed {
+		return handled, nil
+	}
+	if err := proto.Unmarshal(response, res); err != nil {
+		log.Error(ctx, err)
+		return zero, status.Errorf(codes.Internal, "internal error")
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true"))
+	return res, nil
+}
+
+// hash returns the hex SHA-256 of the parts, separated so that they cannot
+// run into each other.
+func hash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
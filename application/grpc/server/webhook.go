@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"m1-article-service/domain/entity"
+	webhookRepo "m1-article-service/domain/repository/webhook"
+	"m1-article-service/domain/service/webhook"
+	articlev1 "m1-article-service/gen/go/article/v1"
+	logger "m1-article-service/infrastructure/log"
+	"time"
+)
+
+type WebhookServer struct {
+	logger         logger.Logger
+	webhookService *webhook.Service
+	articlev1.UnimplementedWebhookServiceServer
+}
+
+func NewWebhookServer(logger logger.Logger, webhookService *webhook.Service) *WebhookServer {
+	return &WebhookServer{logger: logger, webhookService: webhookService}
+}
+
+func (s WebhookServer) CreateWebhook(ctx context.Context, req *articlev1.Webhook) (*articlev1.CreateWebhookResponse, error) {
+	w := &entity.Webhook{URL: req.URL, Secret: req.Secret, Events: req.Events}
+	id, err := s.webhookService.Create(ctx, w)
+	if err != nil {
+		return nil, s.error(ctx, err)
+	}
+	return &articlev1.CreateWebhookResponse{ID: id, Secret: w.Secret}, nil
+}
+
+func (s WebhookServer) ListWebhooks(ctx context.Context, _ *articlev1.Empty) (*articlev1.ListWebhooksResponse, error) {
+	webhooks, err := s.webhookService.List(ctx)
+	if err != nil {
+		return nil, s.error(ctx, err)
+	}
+	res := &articlev1.ListWebhooksResponse{Webhooks: make([]*articlev1.Webhook, len(webhooks))}
+	for i, w := range webhooks {
+		res.Webhooks[i] = &articlev1.Webhook{
+			ID:        w.ID,
+			URL:       w.URL,
+			Events:    w.Events,
+			CreatedAt: uint64(w.CreatedAt.Unix()),
+		}
+	}
+	return res, nil
+}
+
+func (s WebhookServer) DeleteWebhook(ctx context.Context, id *articlev1.WebhookID) (*articlev1.Empty, error) {
+	if err := s.webhookService.Delete(ctx, id.ID); err != nil {
+		return nil, s.error(ctx, err)
+	}
+	return &articlev1.Empty{}, nil
+}
+
+func (s WebhookServer) ListDeliveries(ctx context.Context, req *articlev1.ListDeliveriesRequest) (*articlev1.ListDeliveriesResponse, error) {
+	filter := webhookRepo.DeliveryFilter{WebhookID: req.WebhookID, Status: req.Status}
+	deliveries, err := s.webhookService.Deliveries(ctx, filter, uint16(req.Page))
+	if err != nil {
+		return nil, s.error(ctx, err)
+	}
+	res := &articlev1.ListDeliveriesResponse{Deliveries: make([]*articlev1.Delivery, len(deliveries))}
+	for i, d := range deliveries {
+		res.Deliveries[i] = deliveryToProto(d)
+	}
+	return res, nil
+}
+
+func (s WebhookServer) GetDelivery(ctx context.Context, id *articlev1.DeliveryID) (*articlev1.GetDeliveryResponse, error) {
+	d, attempts, err := s.webhookService.Delivery(ctx, id.ID)
+	if err != nil {
+		return nil, s.error(ctx, err)
+	}
+	res := &articlev1.GetDeliveryResponse{
+		Delivery: deliveryToProto(d),
+		Payload:  string(d.Payload),
+		Attempts: make([]*articlev1.DeliveryAttempt, len(attempts)),
+	}
+	for i, a := range attempts {
+		res.Attempts[i] = &articlev1.DeliveryAttempt{
+			AttemptedAt: a.AttemptedAt.UnixMilli(),
+			StatusCode:  uint32(a.StatusCode),
+			Error:       a.Error,
+			DurationMs:  uint32(a.Duration / time.Millisecond),
+		}
+	}
+	return res, nil
+}
+
+func (s WebhookServer) ReplayDeliveries(ctx context.Context, req *articlev1.ReplayDeliveriesRequest) (*articlev1.ReplayDeliveriesResponse, error) {
+	replayed, err := s.webhookService.Replay(ctx, req.WebhookID, req.DeliveryIDs)
+	if err != nil {
+		return nil, s.error(ctx, err)
+	}
+	return &articlev1.ReplayDeliveriesResponse{Replayed: replayed}, nil
+}
+
+// error maps the errors of the webhook service to statuses.
+func (s WebhookServer) error(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, webhookRepo.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, webhookRepo.ErrNotFound):
+		return status.Errorf(codes.NotFound, "not found")
+	default:
+		s.logger.Error(ctx, err)
+		return status.Errorf(codes.Internal, "internal error")
+	}
+}
+
+func deliveryToProto(d *entity.WebhookDelivery) *articlev1.Delivery {
+	res := &articlev1.Delivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventID:       d.EventID,
+		EventType:     d.EventType,
+		Status:        d.Status,
+		Attempts:      uint32(d.Attempts),
+		NextAttemptAt: d.NextAttemptAt.UnixMilli(),
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt.UnixMilli(),
+	}
+	if !d.DeliveredAt.IsZero() {
+		res.DeliveredAt = d.DeliveredAt.UnixMilli()
+	}
+	return res
+}
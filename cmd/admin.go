@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"m1-article-service/application/grpc/interceptor"
+	articlev1 "m1-article-service/gen/go/article/v1"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/principal"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const adminUsage = `admin [flags] <subcommand> [subcommand flags]
+
+subcommands:
+  create --title T --slug S [--tags a,b]
+  update --id N --title T --slug S [--tags a,b]
+  detail --id N
+  list   [--page N]
+  search --query Q [--page N]
+  delete --id N
+  batch-get    --ids 1,2,3
+  batch-delete --ids 1,2,3
+  audit  [--id N] [--principal P] [--page N]`
+
+// adminCommand is a client of a running server for operators; responses are
+// printed as JSON.
+func adminCommand() *command {
+	var (
+		addr    string
+		as      string
+		timeout time.Duration
+	)
+	return &command{
+		name:     "admin",
+		summary:  "call the RPCs of a running server",
+		usage:    adminUsage,
+		optional: []string{"DATABASE_HOST"},
+		setFlags: func(fs *flag.FlagSet) {
+			fs.StringVar(&addr, "addr", "", "server address (defaults to the configured server address)")
+			fs.StringVar(&as, "as", os.Getenv("USER"), "principal sent with every request when TRUSTED_PROXY_SECRET is set")
+			fs.DurationVar(&timeout, "timeout", 10*time.Second, "timeout of each request")
+		},
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			if len(args) == 0 {
+				return errors.New("usage: " + adminUsage)
+			}
+			if addr == "" {
+				addr = cfg.ServerAddr
+			}
+			conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			// the principal is only honoured along with the proxy secret
+			if as != "" && cfg.TrustedProxySecret != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx,
+					principal.Header, as, interceptor.ProxySecretHeader, cfg.TrustedProxySecret)
+			}
+			res, err := runAdmin(ctx, articlev1.NewArticleServiceClient(conn), args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(res)
+		},
+	}
+}
+
+func runAdmin(ctx context.Context, client articlev1.ArticleServiceClient, name string, args []string) (any, error) {
+	fs := flag.NewFlagSet("admin "+name, flag.ContinueOnError)
+	id := fs.Int64("id", 0, "article ID")
+	title := fs.String("title", "", "article title")
+	slug := fs.String("slug", "", "article slug")
+	tags := fs.String("tags", "", "comma separated tags")
+	page := fs.Uint("page", 1, "page number")
+	ids := fs.String("ids", "", "comma separated article IDs")
+	query := fs.String("query", "", "search query")
+	auditedBy := fs.String("principal", "", "principal of the audited writes")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	article := &articlev1.Article{ID: *id, Title: *title, Slug: *slug, Tags: splitTags(*tags)}
+
+	switch name {
+	case "create":
+		return client.Create(ctx, article)
+	case "update":
+		return client.Update(ctx, article)
+	case "detail":
+		return client.Detail(ctx, &articlev1.ArticleID{ID: *id})
+	case "list":
+		return client.List(ctx, &articlev1.Pagination{Page: uint32(*page)})
+	case "search":
+		return client.Search(ctx, &articlev1.SearchRequest{Query: *query, Page: uint32(*page)})
+	case "delete":
+		return client.Delete(ctx, &articlev1.ArticleID{ID: *id})
+	case "batch-get", "batch-delete":
+		parsed, err := splitIDs(*ids)
+		if err != nil {
+			return nil, err
+		}
+		if name == "batch-get" {
+			return client.BatchGet(ctx, &articlev1.BatchGetRequest{IDs: parsed})
+		}
+		return client.BatchDelete(ctx, &articlev1.BatchDeleteRequest{IDs: parsed})
+	case "audit":
+		return client.ListAudit(ctx, &articlev1.ListAuditRequest{ArticleID: *id, Principal: *auditedBy, Page: uint32(*page)})
+	default:
+		return nil, fmt.Errorf("unknown admin subcommand %q\n\nusage: %s", name, adminUsage)
+	}
+}
+
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	tags := strings.Split(s, ",")
+	for i := range tags {
+		tags[i] = strings.TrimSpace(tags[i])
+	}
+	return tags
+}
+
+func splitIDs(s string) ([]int64, error) {
+	var ids []int64
+	for _, field := range splitTags(s) {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", field)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/repository/article/pgx"
+	"m1-article-service/domain/service/article"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
+	loggerInfra "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/log/zerolog"
+	"os"
+)
+
+// newLogger writes to stderr so that commands can use stdout for their output.
+func newLogger(cfg *config.Config) (loggerInfra.Logger, error) {
+	return zerolog.NewLogger(zerolog.Config{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+		Output: os.Stderr,
+	}, loggerInfra.DefaultContextFields...)
+}
+
+// openArticleService connects to the configured database; the returned
+// pool must be closed by the caller.
+func openArticleService(ctx context.Context, cfg *config.Config) (*article.Service, *pgxpool.Pool, error) {
+	if driver := cfg.DatabaseDriver(); driver != config.DriverPostgres {
+		return nil, nil, fmt.Errorf("this command needs a Postgres database, DATABASE_HOST uses %s", driver)
+	}
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool, err := database.NewPool(ctx, cfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return article.NewService(logger, pgx.NewArticleRepository(cfg, pool)), pool, nil
+}
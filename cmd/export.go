@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"m1-article-service/domain/entity"
+	articleRepo "m1-article-service/domain/repository/article"
+	"m1-article-service/infrastructure/articleio"
+	"m1-article-service/infrastructure/config"
+	"os"
+	"time"
+)
+
+func exportCommand() *command {
+	var (
+		output, format, from, to string
+		filter                   articleRepo.ExportFilter
+	)
+	return &command{
+		name:    "export",
+		summary: "write articles as JSON lines, CSV or Parquet",
+		usage:   "export [flags]",
+		setFlags: func(fs *flag.FlagSet) {
+			fs.StringVar(&output, "output", "-", "file to write to, - for stdout")
+			fs.StringVar(&format, "format", "", "jsonl, csv or parquet, guessed from --output when empty")
+			fs.StringVar(&from, "from", "", "only articles created at or after this date (2006-01-02 or RFC 3339)")
+			fs.StringVar(&to, "to", "", "only articles created at or before this date (2006-01-02 or RFC 3339)")
+			fs.StringVar(&filter.Tag, "tag", "", "only articles with this tag")
+			fs.StringVar(&filter.Status, "status", "", "only articles with this status (draft or published)")
+		},
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			var err error
+			if filter.CreatedFrom, err = parseDate(from, false); err != nil {
+				return fmt.Errorf("--from: %w", err)
+			}
+			if filter.CreatedTo, err = parseDate(to, true); err != nil {
+				return fmt.Errorf("--to: %w", err)
+			}
+			if format == "" {
+				format = articleio.FormatJSONL
+				if output != "-" {
+					if format, err = articleio.FormatFromPath(output); err != nil {
+						return err
+					}
+				}
+			}
+
+			var out io.Writer = os.Stdout
+			if output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			w, err := articleio.NewWriter(format, out)
+			if err != nil {
+				return err
+			}
+
+			service, pool, err := openArticleService(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			if err := service.Export(ctx, filter, func(a *entity.Article) error {
+				return w.Write(a)
+			}); err != nil {
+				return err
+			}
+			return w.Close()
+		},
+	}
+}
+
+// parseDate converts a date or timestamp to Unix seconds. A bare date used
+// as an upper bound covers the whole day.
+func parseDate(value string, endOfDay bool) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return uint64(t.Unix()), nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return 0, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return uint64(t.Unix()), nil
+}
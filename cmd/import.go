@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"m1-article-service/domain/service/article"
+	"m1-article-service/infrastructure/articleio"
+	"m1-article-service/infrastructure/config"
+	"os"
+)
+
+func importCommand() *command {
+	var (
+		format string
+		opts   article.ImportOptions
+	)
+	return &command{
+		name:    "import",
+		summary: "create articles from a JSON lines or CSV file",
+		usage:   "import [flags] <file | ->",
+		setFlags: func(fs *flag.FlagSet) {
+			fs.StringVar(&format, "format", "", "jsonl or csv, guessed from the file extension when empty")
+			fs.BoolVar(&opts.DryRun, "dry-run", false, "validate and write inside a transaction that is rolled back")
+			fs.BoolVar(&opts.UpsertBySlug, "upsert", false, "update articles whose slug already exists instead of creating new ones")
+			fs.IntVar(&opts.BatchSize, "batch-size", article.DefaultImportBatchSize, "articles written per transaction")
+		},
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: import [flags] <file | ->")
+			}
+			var in io.Reader = os.Stdin
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+			if format == "" {
+				if args[0] == "-" {
+					format = articleio.FormatJSONL
+				} else {
+					var err error
+					if format, err = articleio.FormatFromPath(args[0]); err != nil {
+						return err
+					}
+				}
+			}
+			reader, err := articleio.NewReader(format, in)
+			if err != nil {
+				return err
+			}
+
+			service, pool, err := openArticleService(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			report, err := service.Import(ctx, reader, opts)
+			if report != nil {
+				for _, rowErr := range report.Errors {
+					fmt.Fprintf(os.Stderr, "row %d: %v\n", rowErr.Row, rowErr.Err)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			suffix := ""
+			if opts.DryRun {
+				suffix = " (dry run, nothing written)"
+			}
+			fmt.Printf("created %d, updated %d, failed %d articles%s\n",
+				report.Created, report.Updated, report.Failed, suffix)
+			return nil
+		},
+	}
+}
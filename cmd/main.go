@@ -1,7 +1,106 @@
 package main
 
-import "m1-article-service/application/grpc"
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"m1-article-service/infrastructure/config"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// command is a subcommand of the service binary. Every command accepts the
+// configuration flags (see config.LoadFlags) in addition to its own.
+type command struct {
+	name    string
+	summary string
+	// usage is the synopsis printed by help, after the binary name.
+	usage string
+	// optional lists configuration keys the command does not need.
+	optional []string
+	setFlags func(fs *flag.FlagSet)
+	run      func(ctx context.Context, cfg *config.Config, args []string) error
+}
+
+func commands() []*command {
+	return []*command{
+		serveCommand(),
+		migrateCommand(),
+		seedCommand(),
+		exportCommand(),
+		importCommand(),
+		reindexCommand(),
+		adminCommand(),
+	}
+}
 
 func main() {
-	grpc.Boot()
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the command named by the first argument; without one the
+// server is started so existing deployments keep working.
+func run(args []string) int {
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		if len(args) == 0 {
+			usage()
+			return 0
+		}
+		name, args = args[0], []string{"-h"}
+	}
+
+	cmd := find(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		return 2
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "%s\n\nusage: %s %s\n\nflags:\n", cmd.summary, os.Args[0], cmd.usage)
+		fs.PrintDefaults()
+	}
+	if cmd.setFlags != nil {
+		cmd.setFlags(fs)
+	}
+	cfg, rest, err := config.LoadFlags(fs, args, cmd.optional...)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, cfg, rest); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func find(name string) *command {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags] [args]\n\ncommands:\n", os.Args[0])
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s help <command>' for the flags of a command.\n", os.Args[0])
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/migrator"
+	"strconv"
+)
+
+const migrateUsage = `migrate [flags] <up | down [N] | status | goto V | force V>
+
+  up         apply all pending migrations
+  down [N]   revert the last N migrations (default 1)
+  status     print the current and latest schema versions
+  goto V     migrate up or down to version V
+  force V    mark the schema as clean at version V without migrating`
+
+func migrateCommand() *command {
+	return &command{
+		name:    "migrate",
+		summary: "apply or revert the embedded database migrations",
+		usage:   migrateUsage,
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			return runMigrate(cfg, args)
+		},
+	}
+}
+
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: " + migrateUsage)
+	}
+	m, err := migrator.New(cfg.DatabaseURL(), cfg.MigrateLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		err = m.Down(steps)
+	case "goto", "force":
+		if len(args) < 2 {
+			return errors.New("usage: " + migrateUsage)
+		}
+		version, perr := strconv.ParseUint(args[1], 10, 64)
+		if perr != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if args[0] == "goto" {
+			err = m.Goto(uint(version))
+		} else {
+			err = m.Force(uint(version))
+		}
+	case "status":
+	default:
+		return errors.New("usage: " + migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	s, err := m.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d\nlatest: %d\ndirty: %t\n", s.Version, s.Latest, s.Dirty)
+	return nil
+}
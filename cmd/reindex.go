@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
+)
+
+func reindexCommand() *command {
+	return &command{
+		name:    "reindex",
+		summary: "rebuild the indexes of the article tables",
+		usage:   "reindex [flags]",
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			logger, err := newLogger(cfg)
+			if err != nil {
+				return err
+			}
+			pool, err := database.NewPool(ctx, cfg, logger)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			if _, err := pool.Exec(ctx, `REINDEX TABLE articles`); err != nil {
+				return err
+			}
+			fmt.Println("reindexed articles")
+			return nil
+		},
+	}
+}
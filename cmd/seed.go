@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/infrastructure/config"
+)
+
+var seedTags = []string{"go", "grpc", "postgres", "kubernetes", "observability"}
+
+func seedCommand() *command {
+	var count int
+	return &command{
+		name:    "seed",
+		summary: "insert sample articles for local development",
+		usage:   "seed [flags]",
+		setFlags: func(fs *flag.FlagSet) {
+			fs.IntVar(&count, "count", 20, "number of articles to create")
+		},
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			service, pool, err := openArticleService(ctx, cfg)
+			if err != nil {
+				return err
+			}
+			defer pool.Close()
+
+			for i := 1; i <= count; i++ {
+				a := entity.NewArticle(
+					fmt.Sprintf("Sample article %d", i),
+					fmt.Sprintf("sample-article-%d", i),
+					[]string{seedTags[i%len(seedTags)], seedTags[(i+1)%len(seedTags)]},
+				)
+				if _, err := service.Create(ctx, a); err != nil {
+					return fmt.Errorf("creating article %d: %w", i, err)
+				}
+			}
+			fmt.Printf("created %d articles\n", count)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+	"m1-article-service/application/grpc"
+	"m1-article-service/infrastructure/config"
+)
+
+func serveCommand() *command {
+	return &command{
+		name:    "serve",
+		summary: "run the gRPC article server (default command)",
+		usage:   "serve [flags]",
+		run: func(ctx context.Context, cfg *config.Config, args []string) error {
+			grpc.Boot(ctx, cfg)
+			return nil
+		},
+	}
+}
@@ -2,16 +2,23 @@ package entity
 
 import "time"
 
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+)
+
 type Article struct {
 	ID        int64    `json:"ID"`
 	Title     string   `json:"title"`
 	Slug      string   `json:"slug"`
 	Tags      []string `json:"tags"`
 	CreatedAt uint64   `json:"createdAt"`
+	Status    string   `json:"status"`
 }
 
 func NewArticle(title string, slug string, tags []string) *Article {
 	return &Article{Title: title, Slug: slug, Tags: tags,
 		CreatedAt: uint64(time.Now().Unix()),
+		Status:    StatusPublished,
 	}
 }
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Actions of an audit entry.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	// AuditPublish is an update that published the article.
+	AuditPublish = "publish"
+	AuditDelete  = "delete"
+)
+
+// Actor identifies the caller behind a write.
+type Actor struct {
+	Principal string
+	RequestID string
+	// Peer is the network address of the client.
+	Peer string
+}
+
+// AuditEntry records a write of an article with the state of the article
+// before and after it; Before is nil for a creation and After for a
+// deletion.
+type AuditEntry struct {
+	ID         int64
+	Action     string
+	ArticleID  int64
+	Actor      Actor
+	Before     *Article
+	After      *Article
+	OccurredAt time.Time
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Types of the events recorded in the outbox.
+const (
+	EventArticleCreated = "article.created"
+	EventArticleUpdated = "article.updated"
+	// EventArticlePublished follows the created or updated event of an
+	// article whose status became published.
+	EventArticlePublished = "article.published"
+	EventArticleDeleted   = "article.deleted"
+)
+
+// Event is a change of an article, recorded in the transaction that made
+// it. Article is the state after the change, or before it for deletions.
+type Event struct {
+	ID         int64     `json:"ID"`
+	Type       string    `json:"type"`
+	ArticleID  int64     `json:"articleID"`
+	Article    *Article  `json:"article"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
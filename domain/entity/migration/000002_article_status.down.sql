@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS articles_created_at_idx;
+ALTER TABLE articles DROP COLUMN IF EXISTS status;
@@ -0,0 +1,2 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'published';
+CREATE INDEX IF NOT EXISTS articles_created_at_idx ON articles (created_at);
@@ -0,0 +1,13 @@
+-- Titles were not unique before: keep the title of the oldest article and
+-- rename the later duplicates to "<title> (<id>)", truncating the title to
+-- fit, so that the index can be built.
+UPDATE articles a SET title = left(a.title, 50 - length(d.suffix)) || d.suffix
+FROM (
+    SELECT id, ' (' || id || ')' AS suffix
+    FROM (SELECT id, row_number() OVER (PARTITION BY title ORDER BY id) AS n FROM articles) ranked
+    WHERE n > 1
+) d
+WHERE a.id = d.id;
+
+DROP INDEX IF EXISTS articles_title_key;
+CREATE UNIQUE INDEX IF NOT EXISTS articles_title_key ON articles (title);
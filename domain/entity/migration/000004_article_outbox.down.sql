@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS articles_outbox ON articles;
+DROP FUNCTION IF EXISTS article_outbox();
+DROP TABLE IF EXISTS outbox;
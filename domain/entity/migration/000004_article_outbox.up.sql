@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id          BIGSERIAL PRIMARY KEY,
+    type        varchar(32) NOT NULL,
+    article_id  BIGINT NOT NULL,
+    payload     JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- article_outbox records the events of every row written to articles, by
+-- any statement including COPY, in the writing transaction. The payload
+-- uses the JSON names of entity.Article.
+CREATE OR REPLACE FUNCTION article_outbox() RETURNS trigger AS $$
+DECLARE
+    a       articles;
+    payload jsonb;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        a := OLD;
+    ELSE
+        a := NEW;
+    END IF;
+    payload := jsonb_build_object('ID', a.id, 'title', a.title, 'slug', a.slug, 'tags', to_jsonb(a.tags),
+                                  'createdAt', a.created_at, 'status', a.status);
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO outbox (type, article_id, payload) VALUES ('article.created', a.id, payload);
+    ELSIF TG_OP = 'UPDATE' THEN
+        INSERT INTO outbox (type, article_id, payload) VALUES ('article.updated', a.id, payload);
+    ELSE
+        INSERT INTO outbox (type, article_id, payload) VALUES ('article.deleted', a.id, payload);
+    END IF;
+
+    IF TG_OP <> 'DELETE' AND a.status = 'published' AND (TG_OP = 'INSERT' OR OLD.status <> 'published') THEN
+        INSERT INTO outbox (type, article_id, payload) VALUES ('article.published', a.id, payload);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS articles_outbox ON articles;
+CREATE TRIGGER articles_outbox AFTER INSERT OR UPDATE OR DELETE ON articles
+    FOR EACH ROW EXECUTE FUNCTION article_outbox();
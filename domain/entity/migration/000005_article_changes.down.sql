@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS outbox_article_change ON outbox;
+DROP FUNCTION IF EXISTS article_change();
+DROP TABLE IF EXISTS article_changes;
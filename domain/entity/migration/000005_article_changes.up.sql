@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS article_changes (
+    seq         BIGINT PRIMARY KEY,
+    type        varchar(32) NOT NULL,
+    article_id  BIGINT NOT NULL,
+    payload     JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS article_changes_occurred_at_idx ON article_changes (occurred_at);
+
+-- article_change keeps a copy of every outbox event, numbered by its outbox
+-- ID, for watchers resuming after a reconnect, and notifies the listeners
+-- of article_events with that number when the transaction commits.
+CREATE OR REPLACE FUNCTION article_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO article_changes (seq, type, article_id, payload, occurred_at)
+    VALUES (NEW.id, NEW.type, NEW.article_id, NEW.payload, NEW.occurred_at);
+    PERFORM pg_notify('article_events', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS outbox_article_change ON outbox;
+CREATE TRIGGER outbox_article_change AFTER INSERT ON outbox
+    FOR EACH ROW EXECUTE FUNCTION article_change();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS articles_audit ON articles;
+DROP FUNCTION IF EXISTS article_audit();
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
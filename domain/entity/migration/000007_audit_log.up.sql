@@ -0,0 +1,72 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    action      varchar(16) NOT NULL,
+    article_id  BIGINT NOT NULL,
+    principal   TEXT NOT NULL DEFAULT '',
+    request_id  TEXT NOT NULL DEFAULT '',
+    peer        TEXT NOT NULL DEFAULT '',
+    before      JSONB,
+    after       JSONB,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS audit_log_article_id_idx ON audit_log (article_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_principal_idx ON audit_log (principal, id);
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+-- article_audit records every row written to articles, by any statement
+-- including COPY, in the writing transaction. The caller is read from the
+-- transaction local audit.principal, audit.request_id and audit.peer
+-- settings made by the repository. Snapshots use the JSON names of
+-- entity.Article.
+CREATE OR REPLACE FUNCTION article_audit() RETURNS trigger AS $$
+DECLARE
+    audit_action text;
+    before_row   jsonb;
+    after_row    jsonb;
+    row_id       bigint;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        row_id := OLD.id;
+        before_row := jsonb_build_object('ID', OLD.id, 'title', OLD.title, 'slug', OLD.slug, 'tags', to_jsonb(OLD.tags),
+                                         'createdAt', OLD.created_at, 'status', OLD.status);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        row_id := NEW.id;
+        after_row := jsonb_build_object('ID', NEW.id, 'title', NEW.title, 'slug', NEW.slug, 'tags', to_jsonb(NEW.tags),
+                                        'createdAt', NEW.created_at, 'status', NEW.status);
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        audit_action := 'create';
+    ELSIF TG_OP = 'DELETE' THEN
+        audit_action := 'delete';
+    ELSIF OLD.status <> 'published' AND NEW.status = 'published' THEN
+        audit_action := 'publish';
+    ELSE
+        audit_action := 'update';
+    END IF;
+
+    INSERT INTO audit_log (action, article_id, principal, request_id, peer, before, after)
+    VALUES (audit_action, row_id,
+            coalesce(current_setting('audit.principal', true), ''),
+            coalesce(current_setting('audit.request_id', true), ''),
+            coalesce(current_setting('audit.peer', true), ''),
+            before_row, after_row);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS articles_audit ON articles;
+CREATE TRIGGER articles_audit AFTER INSERT OR UPDATE OR DELETE ON articles
+    FOR EACH ROW EXECUTE FUNCTION article_audit();
+
+-- audit_log is append-only; TRUNCATE stays available to operators.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,13 @@
+-- rate_limit_buckets holds the token buckets shared by the replicas, see
+-- infrastructure/ratelimit. Losing them on a crash only resets the limits,
+-- so the table is not WAL-logged.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    -- allowed tells whether the last request took a token
+    allowed    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    -- full_at is when the bucket is refilled and can be dropped
+    full_at    TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_full_at_idx ON rate_limit_buckets (full_at);
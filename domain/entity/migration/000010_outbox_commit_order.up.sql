@@ -0,0 +1,8 @@
+-- xact_id is the transaction that recorded the event. The relay only
+-- dispatches the events of transactions older than every one in progress,
+-- so that an event committed late cannot be overtaken by those recorded
+-- after it.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS xact_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+-- claimed_until is set while a relay delivers the event.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
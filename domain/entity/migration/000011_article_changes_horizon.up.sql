@@ -0,0 +1,10 @@
+-- article_changes_horizon holds the last sequence number pruned from
+-- article_changes, below which watchers cannot resume. The changes pruned
+-- before it existed are assumed to end below the oldest retained one.
+CREATE TABLE IF NOT EXISTS article_changes_horizon (
+    id  BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    seq BIGINT NOT NULL
+);
+INSERT INTO article_changes_horizon (id, seq)
+SELECT true, min(seq) - 1 FROM article_changes HAVING count(*) > 0
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,18 @@
+DROP FUNCTION IF EXISTS sequence_article_changes();
+DELETE FROM article_changes WHERE seq IS NULL;
+ALTER TABLE article_changes DROP CONSTRAINT IF EXISTS article_changes_pkey;
+DROP INDEX IF EXISTS article_changes_unsequenced_idx;
+DROP INDEX IF EXISTS article_changes_seq_idx;
+DROP SEQUENCE IF EXISTS article_changes_seq;
+ALTER TABLE article_changes ALTER COLUMN seq SET NOT NULL;
+ALTER TABLE article_changes ADD PRIMARY KEY (seq);
+ALTER TABLE article_changes DROP COLUMN IF EXISTS outbox_id;
+
+CREATE OR REPLACE FUNCTION article_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO article_changes (seq, type, article_id, payload, occurred_at)
+    VALUES (NEW.id, NEW.type, NEW.article_id, NEW.payload, NEW.occurred_at);
+    PERFORM pg_notify('article_events', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
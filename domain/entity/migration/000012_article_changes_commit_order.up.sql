@@ -0,0 +1,42 @@
+-- article_changes are numbered once committed rather than by their outbox
+-- ID, which is drawn when the event is recorded: a transaction committing
+-- after another could otherwise add an event below one already read.
+-- outbox_id keeps the outbox ID and seq is NULL until sequenced.
+ALTER TABLE article_changes ADD COLUMN IF NOT EXISTS outbox_id BIGINT;
+UPDATE article_changes SET outbox_id = seq WHERE outbox_id IS NULL;
+ALTER TABLE article_changes DROP CONSTRAINT IF EXISTS article_changes_pkey;
+ALTER TABLE article_changes ALTER COLUMN outbox_id SET NOT NULL, ALTER COLUMN seq DROP NOT NULL;
+ALTER TABLE article_changes ADD PRIMARY KEY (outbox_id);
+CREATE UNIQUE INDEX IF NOT EXISTS article_changes_seq_idx ON article_changes (seq);
+CREATE INDEX IF NOT EXISTS article_changes_unsequenced_idx ON article_changes (outbox_id) WHERE seq IS NULL;
+
+CREATE SEQUENCE IF NOT EXISTS article_changes_seq OWNED BY article_changes.seq;
+SELECT setval('article_changes_seq', greatest(
+    (SELECT coalesce(max(seq), 0) FROM article_changes),
+    (SELECT coalesce(max(seq), 0) FROM article_changes_horizon),
+    (SELECT coalesce(max(id), 0) FROM outbox)) + 1, false);
+
+-- article_change keeps a copy of every outbox event for watchers resuming
+-- after a reconnect and notifies the listeners of article_events when the
+-- transaction commits.
+CREATE OR REPLACE FUNCTION article_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO article_changes (outbox_id, type, article_id, payload, occurred_at)
+    VALUES (NEW.id, NEW.type, NEW.article_id, NEW.payload, NEW.occurred_at);
+    PERFORM pg_notify('article_events', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- sequence_article_changes numbers the committed changes that are not yet,
+-- in outbox order. Calls take turns on a lock held until they commit, so
+-- the numbers of a call are above those of every call committed before it.
+CREATE OR REPLACE FUNCTION sequence_article_changes() RETURNS void AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('article_changes_seq'));
+    UPDATE article_changes c SET seq = n.seq
+    FROM (SELECT outbox_id, nextval('article_changes_seq') AS seq
+          FROM (SELECT outbox_id FROM article_changes WHERE seq IS NULL ORDER BY outbox_id) pending) n
+    WHERE c.outbox_id = n.outbox_id;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS articles_search_idx;
+ALTER TABLE articles DROP COLUMN IF EXISTS search;
+DROP FUNCTION IF EXISTS article_search_document(TEXT, TEXT, TEXT[]);
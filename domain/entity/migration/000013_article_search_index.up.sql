@@ -0,0 +1,12 @@
+-- article_search_document is the text searched by Search; the simple
+-- configuration matches whole words without stemming, like the other
+-- backends. array_to_string is only STABLE, so the function is declared
+-- IMMUTABLE for the generated column to accept it; it does not depend on
+-- any setting for text arrays.
+CREATE OR REPLACE FUNCTION article_search_document(title TEXT, slug TEXT, tags TEXT[]) RETURNS tsvector AS $$
+    SELECT to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(slug, '') || ' ' || coalesce(array_to_string(tags, ' '), ''))
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search tsvector
+    GENERATED ALWAYS AS (article_search_document(title, slug, tags::TEXT[])) STORED;
+CREATE INDEX IF NOT EXISTS articles_search_idx ON articles USING GIN (search);
@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// FS holds the Postgres migrations.
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLiteFS holds the migrations of the SQLite backend, versioned
+// independently of the Postgres ones.
+var SQLiteFS, _ = fs.Sub(sqliteFS, "sqlite")
+
+// LatestVersion returns the highest Postgres migration version shipped with
+// the binary.
+func LatestVersion() (uint, error) {
+	return Latest(FS)
+}
+
+// Latest returns the highest migration version in the root of fsys.
+func Latest(fsys fs.FS) (uint, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %q: %w", entry.Name(), err)
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS article_tags_fts_delete;
+DROP TRIGGER IF EXISTS article_tags_fts_insert;
+DROP TRIGGER IF EXISTS articles_fts_delete;
+DROP TRIGGER IF EXISTS articles_fts_update;
+DROP TRIGGER IF EXISTS articles_fts_insert;
+DROP TABLE IF EXISTS articles_fts;
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS articles;
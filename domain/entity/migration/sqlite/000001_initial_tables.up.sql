@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS articles (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    title      TEXT    NOT NULL UNIQUE CHECK (length(title) <= 50),
+    slug       TEXT    NOT NULL CHECK (length(slug) <= 100),
+    created_at INTEGER NOT NULL,
+    status     TEXT    NOT NULL DEFAULT 'published'
+);
+CREATE INDEX IF NOT EXISTS articles_slug_idx ON articles (slug);
+CREATE INDEX IF NOT EXISTS articles_created_at_idx ON articles (created_at);
+
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    position   INTEGER NOT NULL,
+    tag        TEXT    NOT NULL CHECK (length(tag) <= 30),
+    PRIMARY KEY (article_id, position)
+);
+CREATE INDEX IF NOT EXISTS article_tags_tag_idx ON article_tags (tag);
+
+-- articles_fts indexes the title, slug and tags of every article under the
+-- article ID; the triggers keep it in sync with both tables.
+CREATE VIRTUAL TABLE IF NOT EXISTS articles_fts USING fts5(title, slug, tags);
+
+CREATE TRIGGER IF NOT EXISTS articles_fts_insert AFTER INSERT ON articles BEGIN
+    INSERT INTO articles_fts (rowid, title, slug, tags) VALUES (new.id, new.title, new.slug, '');
+END;
+CREATE TRIGGER IF NOT EXISTS articles_fts_update AFTER UPDATE OF title, slug ON articles BEGIN
+    UPDATE articles_fts SET title = new.title, slug = new.slug WHERE rowid = new.id;
+END;
+CREATE TRIGGER IF NOT EXISTS articles_fts_delete AFTER DELETE ON articles BEGIN
+    DELETE FROM articles_fts WHERE rowid = old.id;
+END;
+CREATE TRIGGER IF NOT EXISTS article_tags_fts_insert AFTER INSERT ON article_tags BEGIN
+    UPDATE articles_fts SET tags = (SELECT group_concat(tag, ' ') FROM article_tags WHERE article_id = new.article_id)
+    WHERE rowid = new.article_id;
+END;
+CREATE TRIGGER IF NOT EXISTS article_tags_fts_delete AFTER DELETE ON article_tags BEGIN
+    UPDATE articles_fts SET tags = coalesce((SELECT group_concat(tag, ' ') FROM article_tags WHERE article_id = old.article_id), '')
+    WHERE rowid = old.article_id;
+END;
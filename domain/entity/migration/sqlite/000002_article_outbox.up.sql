@@ -0,0 +1,9 @@
+-- The repository records the events itself: a trigger on articles would run
+-- before the tags of a new article are written.
+CREATE TABLE IF NOT EXISTS outbox (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    type        TEXT    NOT NULL,
+    article_id  INTEGER NOT NULL,
+    payload     TEXT    NOT NULL,
+    occurred_at INTEGER NOT NULL -- Unix nanoseconds
+);
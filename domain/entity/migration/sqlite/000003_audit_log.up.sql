@@ -0,0 +1,27 @@
+-- The repository records the entries itself, with the caller of the write;
+-- before and after are JSON snapshots of the article, NULL for creations
+-- and deletions respectively.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    action      TEXT    NOT NULL,
+    article_id  INTEGER NOT NULL,
+    principal   TEXT    NOT NULL DEFAULT '',
+    request_id  TEXT    NOT NULL DEFAULT '',
+    peer        TEXT    NOT NULL DEFAULT '',
+    before      TEXT,
+    after       TEXT,
+    occurred_at INTEGER NOT NULL -- Unix nanoseconds
+);
+CREATE INDEX IF NOT EXISTS audit_log_article_id_idx ON audit_log (article_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_principal_idx ON audit_log (principal, id);
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
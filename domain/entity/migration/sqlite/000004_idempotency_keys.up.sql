@@ -0,0 +1,9 @@
+-- idempotency_keys maps the idempotency keys of client requests to their
+-- responses; response is NULL while the request is in progress.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key          TEXT    PRIMARY KEY,
+    request_hash TEXT    NOT NULL,
+    response     BLOB,
+    expires_at   INTEGER NOT NULL -- Unix nanoseconds
+);
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Statuses of a webhook delivery.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryDead failed every attempt; it is only retried when replayed.
+	DeliveryDead = "dead"
+)
+
+// Webhook subscribes URL to article events. Secret signs the deliveries;
+// an empty Events subscribes to every event type.
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+}
+
+// Matches reports whether the webhook subscribes to events of eventType.
+func (w *Webhook) Matches(eventType string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// WebhookDelivery is the delivery of one event to one webhook. Payload is
+// the JSON encoded Event, sent as the request body.
+type WebhookDelivery struct {
+	ID            int64
+	WebhookID     int64
+	EventID       int64
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	// DeliveredAt is zero until the delivery succeeds.
+	DeliveredAt time.Time
+}
+
+// WebhookAttempt is the log entry of one request of a delivery. StatusCode
+// is zero when no response was received.
+type WebhookAttempt struct {
+	DeliveryID  int64
+	AttemptedAt time.Time
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+}
@@ -4,18 +4,157 @@ import (
 	"context"
 	"errors"
 	"m1-article-service/domain/entity"
+	"time"
 )
 
 var (
 	ErrAlreadyExist = errors.New("already exist")
 	ErrValidation   = errors.New("validation error")
 	ErrNotFound     = errors.New("not found")
+	// ErrBatchAborted is the result of batch items that were valid but not
+	// written because another item of the batch failed.
+	ErrBatchAborted = errors.New("batch aborted")
+	// ErrPruned is returned by Changes.Since when events after the given
+	// sequence number are no longer retained.
+	ErrPruned = errors.New("events pruned")
 )
 
+// Article stores articles. Implementations assign increasing IDs that are
+// never reused, keep titles unique (ErrAlreadyExist), return ErrNotFound
+// for missing IDs from Detail, Update and Delete, and list pages of ten in
+// ID order. Update replaces the title, slug, tags and status of an article,
+// keeping its status when the given one is empty. The articletest package
+// checks these rules.
 type Article interface {
 	Create(context.Context, *entity.Article) (int64, error)
 	Update(context.Context, *entity.Article) error
 	Delete(context.Context, int64) error
 	Detail(context.Context, int64) (*entity.Article, error)
 	List(context.Context, uint16) ([]*entity.Article, error)
+	// Search returns a page of the articles whose title, slug or tags
+	// contain every word of the query, ignoring case, best matches first.
+	Search(context.Context, string, uint16) ([]*entity.Article, error)
+	// Import writes a batch of articles in a single transaction. A row that
+	// fails is reported in its ImportResult without preventing the other
+	// rows from being written; the returned error is reserved for failures
+	// of the whole batch.
+	Import(context.Context, []*entity.Article, ImportOptions) ([]ImportResult, error)
+	// Export calls fn for every article matching the filter, in ID order,
+	// reading from a single consistent snapshot. Returning an error from fn
+	// stops the export with that error.
+	Export(context.Context, ExportFilter, func(*entity.Article) error) error
+	// BatchDetail fetches many articles in one query. The result is in the
+	// order of the IDs, with nil for the IDs that do not exist.
+	BatchDetail(context.Context, []int64) ([]*entity.Article, error)
+	// BatchCreate and BatchDelete apply every item or none. When an item
+	// fails, its result carries the error, the other results carry
+	// ErrBatchAborted and nothing is written; the returned error is reserved
+	// for failures of the whole batch.
+	BatchCreate(context.Context, []*entity.Article) ([]BatchResult, error)
+	BatchDelete(context.Context, []int64) ([]BatchResult, error)
+}
+
+// Outbox holds the events recorded along with every write of an Article
+// repository, in the same transaction.
+type Outbox interface {
+	// Dispatch calls fn with up to limit pending events in the order they
+	// were recorded and removes those fn accepted. It stops at the first
+	// error and returns it with the number of accepted events, leaving the
+	// failed event first in line. Concurrent calls deliver one at a time.
+	Dispatch(ctx context.Context, limit int, fn func(*entity.Event) error) (int, error)
+}
+
+// Changes is the log of the events recorded in the outbox, kept for a
+// retention period after their delivery. The sequence number of an event
+// is its ID; events are numbered once committed, so an event is never
+// numbered below one that was already read.
+type Changes interface {
+	// Since returns up to limit events with an ID above after, in order,
+	// or ErrPruned when after is not zero and some of them were pruned.
+	Since(ctx context.Context, after int64, limit int) ([]*entity.Event, error)
+	// Listen calls ready once it receives events, then fn with every event
+	// as it is committed until ctx is done or the feed fails. Events
+	// committed while nobody listens can only be read with Since.
+	Listen(ctx context.Context, ready func(), fn func(*entity.Event)) error
+	// Prune drops the events that occurred before t.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+type ImportOptions struct {
+	// DryRun rolls the transaction back after writing the batch.
+	DryRun bool
+	// UpsertBySlug updates the article with the same slug instead of
+	// inserting a new one.
+	UpsertBySlug bool
+}
+
+// SupersededBy returns, for every article of the batch, the index of the
+// last article with the same slug when UpsertBySlug is set and that is a
+// later one, or -1. Only the last article of a slug is written; it would
+// update the earlier ones anyway.
+func (o ImportOptions) SupersededBy(articles []*entity.Article) []int {
+	by := make([]int, len(articles))
+	last := make(map[string]int, len(articles))
+	for i, a := range articles {
+		by[i] = -1
+		if o.UpsertBySlug {
+			last[a.Slug] = i
+		}
+	}
+	for i, a := range articles {
+		if j, ok := last[a.Slug]; ok && j != i {
+			by[i] = j
+		}
+	}
+	return by
+}
+
+type ImportResult struct {
+	// Updated is true when an existing article was updated.
+	Updated bool
+	// Published is true when the row created a published article or
+	// published a stored one.
+	Published bool
+	Err       error
+}
+
+// ResolveSuperseded gives the articles skipped for a later one with the same
+// slug the outcome of that article: updated by it, or failed with its error.
+func ResolveSuperseded(articles []*entity.Article, results []ImportResult, supersededBy []int) {
+	for i, j := range supersededBy {
+		if j < 0 {
+			continue
+		}
+		results[i] = ImportResult{Updated: results[j].Err == nil, Err: results[j].Err}
+		if results[j].Err == nil {
+			articles[i].ID = articles[j].ID
+		}
+	}
+}
+
+// ExportFilter selects the exported articles; zero fields match everything.
+type ExportFilter struct {
+	// CreatedFrom and CreatedTo bound the creation time in Unix seconds,
+	// inclusive.
+	CreatedFrom uint64
+	CreatedTo   uint64
+	Tag         string
+	Status      string
+}
+
+type BatchResult struct {
+	// ID is the created or deleted article; it is only meaningful when Err
+	// is nil.
+	ID  int64
+	Err error
+}
+
+// BatchApplied reports whether every item of a batch was written.
+func BatchApplied(results []BatchResult) bool {
+	for _, res := range results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	return true
 }
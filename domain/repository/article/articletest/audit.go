@@ -0,0 +1,227 @@
+package articletest
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/remoteaddr"
+	"m1-article-service/infrastructure/requestid"
+	"reflect"
+	"testing"
+)
+
+// RunAudit checks the audit entries an implementation records along with
+// its writes. newRepo must return an empty repository and an empty audit
+// log recording its writes.
+func RunAudit(t *testing.T, newRepo func(t *testing.T) (article.Article, article.AuditLog)) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo article.Article, log article.AuditLog)
+	}{
+		{"Entries", testAuditEntries},
+		{"ImportPublishes", testAuditImportPublishes},
+		{"RolledBackWrites", testAuditRolledBack},
+		{"Filter", testAuditFilter},
+		{"Pages", testAuditPages},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo, log := newRepo(t)
+			test.test(t, repo, log)
+		})
+	}
+}
+
+// auditEntry is the comparable part of an entity.AuditEntry; Before and
+// After are the titles of the snapshots.
+type auditEntry struct {
+	Action    string
+	ArticleID int64
+	Principal string
+	Before    string
+	After     string
+}
+
+func actorContext(name string) context.Context {
+	ctx := principal.NewContext(context.Background(), name)
+	ctx = requestid.NewContext(ctx, "request-"+name)
+	return remoteaddr.NewContext(ctx, "10.0.0.1:4000")
+}
+
+// audit returns a page of entries matching filter, checking the fields
+// that are not compared.
+func audit(t *testing.T, log article.AuditLog, filter article.AuditFilter, page uint16) []auditEntry {
+	t.Helper()
+	entries, err := log.Audit(context.Background(), filter, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]auditEntry, len(entries))
+	for i, e := range entries {
+		if e.ID == 0 || e.OccurredAt.IsZero() {
+			t.Errorf("incomplete entry %+v", e)
+		}
+		if e.Actor.Principal != "" && (e.Actor.RequestID != "request-"+e.Actor.Principal || e.Actor.Peer != "10.0.0.1:4000") {
+			t.Errorf("actor = %+v", e.Actor)
+		}
+		got[i] = auditEntry{Action: e.Action, ArticleID: e.ArticleID, Principal: e.Actor.Principal}
+		if e.Before != nil {
+			got[i].Before = e.Before.Title
+			if e.Before.ID != e.ArticleID {
+				t.Errorf("before snapshot of article %d in entry of %d", e.Before.ID, e.ArticleID)
+			}
+		}
+		if e.After != nil {
+			got[i].After = e.After.Title
+			if e.After.ID != e.ArticleID {
+				t.Errorf("after snapshot of article %d in entry of %d", e.After.ID, e.ArticleID)
+			}
+		}
+	}
+	return got
+}
+
+func testAuditEntries(t *testing.T, repo article.Article, log article.AuditLog) {
+	alice, bob := actorContext("alice"), actorContext("bob")
+	id, err := repo.Create(alice, newArticle(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(bob, &entity.Article{ID: id, Title: "renamed", Slug: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []auditEntry{
+		{entity.AuditDelete, id, "", "renamed", ""},
+		{entity.AuditUpdate, id, "bob", "title 1", "renamed"},
+		{entity.AuditCreate, id, "alice", "", "title 1"},
+	}
+	if got := audit(t, log, article.AuditFilter{}, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("entries = %+v, want %+v", got, want)
+	}
+}
+
+func testAuditImportPublishes(t *testing.T, repo article.Article, log article.AuditLog) {
+	ctx := actorContext("alice")
+	draft := newArticle(1)
+	draft.Status = entity.StatusDraft
+	id := create(t, repo, draft)
+
+	_, err := repo.Import(ctx, []*entity.Article{newArticle(1), newArticle(2)}, article.ImportOptions{UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.BatchDelete(ctx, []int64{id}); err != nil {
+		t.Fatal(err)
+	}
+	articles, err := repo.List(context.Background(), 1)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("List = %+v, %v", articles, err)
+	}
+	want := []auditEntry{
+		{entity.AuditDelete, id, "alice", "title 1", ""},
+		{entity.AuditCreate, articles[0].ID, "alice", "", "title 2"},
+		{entity.AuditPublish, id, "alice", "title 1", "title 1"},
+	}
+	got := audit(t, log, article.AuditFilter{Principal: "alice"}, 1)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entries = %+v, want %+v", got, want)
+	}
+}
+
+func testAuditRolledBack(t *testing.T, repo article.Article, log article.AuditLog) {
+	ctx := actorContext("alice")
+	create(t, repo, newArticle(1))
+
+	if _, err := repo.Import(ctx, []*entity.Article{newArticle(2)}, article.ImportOptions{DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.BatchCreate(ctx, []*entity.Article{newArticle(3), newArticle(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Create(ctx, newArticle(1)); !errors.Is(err, article.ErrAlreadyExist) {
+		t.Fatalf("duplicate create error = %v", err)
+	}
+	if err := repo.Delete(ctx, 1000); !errors.Is(err, article.ErrNotFound) {
+		t.Fatalf("delete of a missing article error = %v", err)
+	}
+	if got := audit(t, log, article.AuditFilter{Principal: "alice"}, 1); len(got) != 0 {
+		t.Errorf("writes that were rolled back recorded %+v", got)
+	}
+}
+
+func testAuditFilter(t *testing.T, repo article.Article, log article.AuditLog) {
+	first, err := repo.Create(actorContext("alice"), newArticle(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.Create(actorContext("bob"), newArticle(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(actorContext("alice"), second); err != nil {
+		t.Fatal(err)
+	}
+
+	byArticle := audit(t, log, article.AuditFilter{ArticleID: second}, 1)
+	if len(byArticle) != 2 || byArticle[0].Action != entity.AuditDelete || byArticle[1].Principal != "bob" {
+		t.Errorf("entries of article %d = %+v", second, byArticle)
+	}
+	byPrincipal := audit(t, log, article.AuditFilter{Principal: "alice"}, 1)
+	want := []auditEntry{
+		{entity.AuditDelete, second, "alice", "title 2", ""},
+		{entity.AuditCreate, first, "alice", "", "title 1"},
+	}
+	if !reflect.DeepEqual(byPrincipal, want) {
+		t.Errorf("entries of alice = %+v, want %+v", byPrincipal, want)
+	}
+
+	// The bounds are taken from the entries, the clock of the database may
+	// differ from the one of the test.
+	entries, err := log.Audit(context.Background(), article.AuditFilter{}, 1)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Audit = %d entries, %v", len(entries), err)
+	}
+	created := entries[2].OccurredAt
+	if got := audit(t, log, article.AuditFilter{From: created, To: created}, 1); len(got) == 0 || got[len(got)-1].ArticleID != first {
+		t.Errorf("entries at %v = %+v", created, got)
+	}
+	if got := audit(t, log, article.AuditFilter{From: entries[0].OccurredAt.Add(1)}, 1); len(got) != 0 {
+		t.Errorf("entries after the last one = %+v", got)
+	}
+	if got := audit(t, log, article.AuditFilter{To: created.Add(-1)}, 1); len(got) != 0 {
+		t.Errorf("entries before the first one = %+v", got)
+	}
+}
+
+func testAuditPages(t *testing.T, repo article.Article, log article.AuditLog) {
+	var created []int64
+	for i := 1; i <= 12; i++ {
+		created = append(created, create(t, repo, newArticle(i)))
+	}
+	var got []int64
+	for page := uint16(1); page <= 3; page++ {
+		entries := audit(t, log, article.AuditFilter{}, page)
+		if page < 3 && len(entries) == 0 {
+			t.Fatalf("page %d is empty", page)
+		}
+		for _, e := range entries {
+			got = append(got, e.ArticleID)
+		}
+	}
+	var want []int64
+	for i := len(created) - 1; i >= 0; i-- {
+		want = append(want, created[i])
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paged entries of articles %v, want %v", got, want)
+	}
+	if got := len(audit(t, log, article.AuditFilter{}, 1)); got != 10 {
+		t.Errorf("first page has %d entries, want 10", got)
+	}
+}
@@ -0,0 +1,129 @@
+package articletest
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// RunChanges checks the change feed of an implementation. newRepo must
+// return an empty repository and its empty change log.
+func RunChanges(t *testing.T, newRepo func(t *testing.T) (article.Article, article.Changes)) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo article.Article, changes article.Changes)
+	}{
+		{"Since", testChangesSince},
+		{"Listen", testChangesListen},
+		{"Prune", testChangesPrune},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo, changes := newRepo(t)
+			test.test(t, repo, changes)
+		})
+	}
+}
+
+func since(t *testing.T, changes article.Changes, after int64, limit int) []*entity.Event {
+	t.Helper()
+	events, err := changes.Since(context.Background(), after, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func testChangesSince(t *testing.T, repo article.Article, changes article.Changes) {
+	for i := 0; i < 3; i++ {
+		create(t, repo, newArticle(i))
+	}
+	all := since(t, changes, 0, 100)
+	if len(all) != 6 {
+		t.Fatalf("got %d events, want 6", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].ID <= all[i-1].ID {
+			t.Fatalf("event %d follows event %d", all[i].ID, all[i-1].ID)
+		}
+	}
+	page := since(t, changes, all[1].ID, 2)
+	if len(page) != 2 || page[0].ID != all[2].ID || page[1].ID != all[3].ID {
+		t.Errorf("page after %d = %+v", all[1].ID, page)
+	}
+}
+
+func testChangesListen(t *testing.T, repo article.Article, changes article.Changes) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan struct{})
+	received := make(chan *entity.Event, 10)
+	stopped := make(chan error)
+	go func() {
+		stopped <- changes.Listen(ctx, func() { close(ready) }, func(e *entity.Event) { received <- e })
+	}()
+	select {
+	case <-ready:
+	case err := <-stopped:
+		t.Fatalf("listen stopped: %v", err)
+	}
+
+	first := create(t, repo, newArticle(1))
+	// A rolled back batch notifies nothing.
+	if _, err := repo.BatchCreate(ctx, []*entity.Article{newArticle(2), newArticle(1)}); err != nil {
+		t.Fatal(err)
+	}
+	second := create(t, repo, newArticle(3))
+
+	var got []event
+	timeout := time.After(5 * time.Second)
+	for len(got) < 4 {
+		select {
+		case e := <-received:
+			got = append(got, event{e.Type, e.ArticleID, e.Article.Title})
+		case <-timeout:
+			t.Fatalf("received only %+v", got)
+		}
+	}
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Errorf("listen = %v after cancel", err)
+	}
+
+	want := []event{
+		{entity.EventArticleCreated, first, "title 1"},
+		{entity.EventArticlePublished, first, "title 1"},
+		{entity.EventArticleCreated, second, "title 3"},
+		{entity.EventArticlePublished, second, "title 3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v, want %+v", got, want)
+	}
+}
+
+func testChangesPrune(t *testing.T, repo article.Article, changes article.Changes) {
+	ctx := context.Background()
+	create(t, repo, newArticle(1))
+	if err := changes.Prune(ctx, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	all := since(t, changes, 0, 100)
+	if len(all) != 2 {
+		t.Fatalf("pruning older events removed recent ones, %d left", len(all))
+	}
+	if err := changes.Prune(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if events := since(t, changes, 0, 100); len(events) != 0 {
+		t.Errorf("%d events left after pruning all", len(events))
+	}
+	if _, err := changes.Since(ctx, all[0].ID, 100); !errors.Is(err, article.ErrPruned) {
+		t.Errorf("Since a pruned event = %v, want ErrPruned", err)
+	}
+	if events := since(t, changes, all[1].ID, 100); len(events) != 0 {
+		t.Errorf("Since the last pruned event = %+v, want none", events)
+	}
+}
@@ -0,0 +1,179 @@
+package articletest
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"reflect"
+	"testing"
+)
+
+// RunOutbox checks the events an implementation records along with its
+// writes. newRepo must return an empty repository and an empty outbox
+// recording its events.
+func RunOutbox(t *testing.T, newRepo func(t *testing.T) (article.Article, article.Outbox)) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo article.Article, outbox article.Outbox)
+	}{
+		{"Events", testOutboxEvents},
+		{"ImportPublishes", testOutboxImportPublishes},
+		{"RolledBackWrites", testOutboxRolledBack},
+		{"DispatchStopsAtError", testOutboxDispatchError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo, outbox := newRepo(t)
+			test.test(t, repo, outbox)
+		})
+	}
+}
+
+type event struct {
+	Type      string
+	ArticleID int64
+	Title     string
+}
+
+// dispatchAll removes and returns every pending event.
+func dispatchAll(t *testing.T, outbox article.Outbox) []event {
+	t.Helper()
+	var events []event
+	_, err := outbox.Dispatch(context.Background(), 1000, func(e *entity.Event) error {
+		if e.ID == 0 || e.OccurredAt.IsZero() || e.Article == nil || e.Article.ID != e.ArticleID {
+			t.Errorf("incomplete event %+v", e)
+			return nil
+		}
+		events = append(events, event{e.Type, e.ArticleID, e.Article.Title})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func testOutboxEvents(t *testing.T, repo article.Article, outbox article.Outbox) {
+	ctx := context.Background()
+	published := create(t, repo, newArticle(1))
+	draft := newArticle(2)
+	draft.Status = entity.StatusDraft
+	create(t, repo, draft)
+	if err := repo.Update(ctx, &entity.Article{ID: published, Title: "renamed", Slug: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	draft.Status = entity.StatusPublished
+	if err := repo.Update(ctx, draft); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, published); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []event{
+		{entity.EventArticleCreated, published, "title 1"},
+		{entity.EventArticlePublished, published, "title 1"},
+		{entity.EventArticleCreated, draft.ID, "title 2"},
+		{entity.EventArticleUpdated, published, "renamed"},
+		{entity.EventArticleUpdated, draft.ID, "title 2"},
+		{entity.EventArticlePublished, draft.ID, "title 2"},
+		{entity.EventArticleDeleted, published, "renamed"},
+	}
+	if got := dispatchAll(t, outbox); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v, want %+v", got, want)
+	}
+	if got := dispatchAll(t, outbox); len(got) != 0 {
+		t.Errorf("dispatched events were delivered again: %+v", got)
+	}
+}
+
+func testOutboxImportPublishes(t *testing.T, repo article.Article, outbox article.Outbox) {
+	draft := newArticle(1)
+	draft.Status = entity.StatusDraft
+	id := create(t, repo, draft)
+	dispatchAll(t, outbox)
+
+	newDraft := newArticle(2)
+	newDraft.Status = entity.StatusDraft
+	results, err := repo.Import(context.Background(), []*entity.Article{newArticle(1), newDraft, newArticle(3)},
+		article.ImportOptions{UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Imports need not set the IDs of created articles.
+	stored, err := repo.List(context.Background(), 1)
+	if err != nil || len(stored) != 3 {
+		t.Fatalf("list = %v, %v", stored, err)
+	}
+	want := []event{
+		{entity.EventArticleUpdated, id, "title 1"},
+		{entity.EventArticlePublished, id, "title 1"},
+		{entity.EventArticleCreated, stored[1].ID, "title 2"},
+		{entity.EventArticleCreated, stored[2].ID, "title 3"},
+		{entity.EventArticlePublished, stored[2].ID, "title 3"},
+	}
+	if got := dispatchAll(t, outbox); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %+v, want %+v", got, want)
+	}
+	if published := []bool{results[0].Published, results[1].Published, results[2].Published}; !reflect.DeepEqual(published, []bool{true, false, true}) {
+		t.Errorf("published results = %v, want [true false true]", published)
+	}
+
+	results, err = repo.Import(context.Background(), []*entity.Article{newArticle(1)}, article.ImportOptions{UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Published {
+		t.Error("updating a published article reported it as published")
+	}
+}
+
+func testOutboxRolledBack(t *testing.T, repo article.Article, outbox article.Outbox) {
+	ctx := context.Background()
+	create(t, repo, newArticle(1))
+	dispatchAll(t, outbox)
+
+	if _, err := repo.Import(ctx, []*entity.Article{newArticle(2)}, article.ImportOptions{DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.BatchCreate(ctx, []*entity.Article{newArticle(3), newArticle(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Create(ctx, newArticle(1)); !errors.Is(err, article.ErrAlreadyExist) {
+		t.Fatalf("duplicate create error = %v", err)
+	}
+	if got := dispatchAll(t, outbox); len(got) != 0 {
+		t.Errorf("writes that were rolled back recorded %+v", got)
+	}
+}
+
+func testOutboxDispatchError(t *testing.T, repo article.Article, outbox article.Outbox) {
+	ctx := context.Background()
+	ids := make([]int64, 3)
+	for i := range ids {
+		ids[i] = create(t, repo, newArticle(i))
+	}
+
+	failed := errors.New("sink down")
+	var first []string
+	n, err := outbox.Dispatch(ctx, 100, func(e *entity.Event) error {
+		if len(first) == 3 {
+			return failed
+		}
+		first = append(first, e.Type)
+		return nil
+	})
+	if n != 3 || !errors.Is(err, failed) {
+		t.Fatalf("dispatch = %d, %v, want 3 and the sink error", n, err)
+	}
+
+	want := []event{
+		{entity.EventArticlePublished, ids[1], "title 1"},
+		{entity.EventArticleCreated, ids[2], "title 2"},
+		{entity.EventArticlePublished, ids[2], "title 2"},
+	}
+	if got := dispatchAll(t, outbox); !reflect.DeepEqual(got, want) {
+		t.Errorf("events after a failed dispatch = %+v, want %+v", got, want)
+	}
+}
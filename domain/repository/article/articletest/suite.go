@@ -0,0 +1,564 @@
+// Package articletest is a conformance suite for implementations of the
+// article.Article repository interface. Every implementation runs it from
+// its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		articletest.Run(t, func(t *testing.T) article.Article { return NewArticleRepository() })
+//	}
+package articletest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// PageSize is the number of articles List returns per page.
+const PageSize = 10
+
+// Run runs every conformance test. newRepo must return an empty repository
+// whose first assigned ID is greater than every ID in use before.
+func Run(t *testing.T, newRepo func(t *testing.T) article.Article) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo article.Article)
+	}{
+		{"CreateDetailRoundTrip", testCreateDetail},
+		{"IDsIncrease", testIDsIncrease},
+		{"NotFound", testNotFound},
+		{"DuplicateTitle", testDuplicateTitle},
+		{"Update", testUpdate},
+		{"UpdateStatus", testUpdateStatus},
+		{"Delete", testDelete},
+		{"Pagination", testPagination},
+		{"PaginationStable", testPaginationStable},
+		{"Search", testSearch},
+		{"Import", testImport},
+		{"ImportDuplicateSlugs", testImportDuplicateSlugs},
+		{"ImportDryRun", testImportDryRun},
+		{"Export", testExport},
+		{"BatchDetail", testBatchDetail},
+		{"BatchCreateAtomic", testBatchCreateAtomic},
+		{"BatchDeleteAtomic", testBatchDeleteAtomic},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"ConcurrentDuplicate", testConcurrentDuplicate},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, newRepo(t))
+		})
+	}
+}
+
+func newArticle(n int) *entity.Article {
+	return &entity.Article{
+		Title:     fmt.Sprintf("title %d", n),
+		Slug:      fmt.Sprintf("slug-%d", n),
+		Tags:      []string{"tag", fmt.Sprintf("tag-%d", n)},
+		CreatedAt: uint64(1700000000 + n),
+		Status:    entity.StatusPublished,
+	}
+}
+
+func create(t *testing.T, repo article.Article, a *entity.Article) int64 {
+	t.Helper()
+	id, err := repo.Create(context.Background(), a)
+	if err != nil {
+		t.Fatalf("create %q: %v", a.Title, err)
+	}
+	return id
+}
+
+func ids(articles []*entity.Article) []int64 {
+	ids := make([]int64, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func testCreateDetail(t *testing.T, repo article.Article) {
+	want := newArticle(1)
+	want.Status = entity.StatusDraft
+	id := create(t, repo, want)
+	if id <= 0 || want.ID != id {
+		t.Fatalf("create returned %d and set ID %d", id, want.ID)
+	}
+	got, err := repo.Detail(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("detail = %+v, want %+v", got, want)
+	}
+}
+
+func testIDsIncrease(t *testing.T, repo article.Article) {
+	var last int64
+	for i := 0; i < 5; i++ {
+		id := create(t, repo, newArticle(i))
+		if id <= last {
+			t.Fatalf("ID %d assigned after %d", id, last)
+		}
+		last = id
+	}
+	// IDs of deleted articles are not reused.
+	if err := repo.Delete(context.Background(), last); err != nil {
+		t.Fatal(err)
+	}
+	if id := create(t, repo, newArticle(5)); id <= last {
+		t.Errorf("ID %d reused after deleting %d", id, last)
+	}
+}
+
+func testNotFound(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	missing := create(t, repo, newArticle(1)) + 1000
+	if _, err := repo.Detail(ctx, missing); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("Detail error = %v, want ErrNotFound", err)
+	}
+	a := newArticle(2)
+	a.ID = missing
+	if err := repo.Update(ctx, a); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("Update error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Delete(ctx, missing); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("Delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDuplicateTitle(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	create(t, repo, newArticle(1))
+	second := create(t, repo, newArticle(2))
+
+	duplicate := newArticle(3)
+	duplicate.Title = newArticle(1).Title
+	if _, err := repo.Create(ctx, duplicate); !errors.Is(err, article.ErrAlreadyExist) {
+		t.Errorf("Create error = %v, want ErrAlreadyExist", err)
+	}
+	duplicate.ID = second
+	if err := repo.Update(ctx, duplicate); !errors.Is(err, article.ErrAlreadyExist) {
+		t.Errorf("Update error = %v, want ErrAlreadyExist", err)
+	}
+	if a, _ := repo.Detail(ctx, second); a.Title != newArticle(2).Title {
+		t.Errorf("failed update changed the title to %q", a.Title)
+	}
+}
+
+func testUpdate(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	original := newArticle(1)
+	id := create(t, repo, original)
+
+	update := &entity.Article{ID: id, Title: "new title", Slug: "new-slug", Tags: []string{"new"}}
+	if err := repo.Update(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	got, err := repo.Detail(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := *original
+	want.Title, want.Slug, want.Tags = update.Title, update.Slug, update.Tags
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("detail after update = %+v, want %+v", *got, want)
+	}
+	// An article may keep its own title.
+	if err := repo.Update(ctx, update); err != nil {
+		t.Errorf("update keeping the title: %v", err)
+	}
+}
+
+func testUpdateStatus(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	draft := newArticle(1)
+	draft.Status = entity.StatusDraft
+	id := create(t, repo, draft)
+
+	status := func() string {
+		t.Helper()
+		got, err := repo.Detail(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.Status
+	}
+	if err := repo.Update(ctx, &entity.Article{ID: id, Title: "title 1", Slug: "slug-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(); got != entity.StatusDraft {
+		t.Errorf("status after update without status = %q, want %q", got, entity.StatusDraft)
+	}
+	if err := repo.Update(ctx, &entity.Article{ID: id, Title: "title 1", Slug: "slug-1", Status: entity.StatusPublished}); err != nil {
+		t.Fatal(err)
+	}
+	if got := status(); got != entity.StatusPublished {
+		t.Errorf("status after publishing = %q, want %q", got, entity.StatusPublished)
+	}
+}
+
+func testDelete(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	id := create(t, repo, newArticle(1))
+	kept := create(t, repo, newArticle(2))
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Detail(ctx, id); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("detail after delete error = %v", err)
+	}
+	if _, err := repo.Detail(ctx, kept); err != nil {
+		t.Errorf("other article: %v", err)
+	}
+	// The title of a deleted article is free again.
+	if _, err := repo.Create(ctx, newArticle(1)); err != nil {
+		t.Errorf("recreate: %v", err)
+	}
+}
+
+func testPagination(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	var created []int64
+	for i := 0; i < 2*PageSize+5; i++ {
+		created = append(created, create(t, repo, newArticle(i)))
+	}
+
+	var listed []int64
+	for page := uint16(1); ; page++ {
+		articles, err := repo.List(ctx, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(articles) > PageSize {
+			t.Fatalf("page %d has %d articles", page, len(articles))
+		}
+		if len(articles) == 0 {
+			break
+		}
+		listed = append(listed, ids(articles)...)
+	}
+	if !reflect.DeepEqual(listed, created) {
+		t.Errorf("listed IDs %v, want %v in ID order", listed, created)
+	}
+
+	first, err := repo.List(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(first), created[:PageSize]) {
+		t.Errorf("page 0 = %v, want the first page", ids(first))
+	}
+	if beyond, err := repo.List(ctx, 1000); err != nil || len(beyond) != 0 {
+		t.Errorf("page beyond the end = %v, %v", ids(beyond), err)
+	}
+}
+
+func testPaginationStable(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	for i := 0; i < PageSize+3; i++ {
+		create(t, repo, newArticle(i))
+	}
+	before, err := repo.List(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Updates and new articles do not move articles between pages.
+	if err := repo.Update(ctx, &entity.Article{ID: before[0].ID, Title: "zzz", Slug: "zzz"}); err != nil {
+		t.Fatal(err)
+	}
+	create(t, repo, newArticle(100))
+	for i := 0; i < 3; i++ {
+		after, err := repo.List(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ids(after), ids(before)) {
+			t.Fatalf("first page changed from %v to %v", ids(before), ids(after))
+		}
+	}
+}
+
+func testSearch(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	generics := create(t, repo, &entity.Article{Title: "Generics in Go", Slug: "generics", Tags: []string{"language"}, Status: entity.StatusPublished})
+	channels := create(t, repo, &entity.Article{Title: "Channels", Slug: "go-channels", Tags: []string{"concurrency"}, Status: entity.StatusPublished})
+	create(t, repo, &entity.Article{Title: "Rust traits", Slug: "traits", Tags: []string{"language"}, Status: entity.StatusPublished})
+
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{"go", []int64{generics, channels}},
+		{"GENERICS", []int64{generics}},
+		{"language go", []int64{generics}},
+		{"concurrency", []int64{channels}},
+		{"python", nil},
+	}
+	for _, test := range tests {
+		articles, err := repo.Search(ctx, test.query, 1)
+		if err != nil {
+			t.Fatalf("search %q: %v", test.query, err)
+		}
+		got := ids(articles)
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if len(got) != len(test.want) || (len(got) > 0 && !reflect.DeepEqual(got, test.want)) {
+			t.Errorf("search %q = %v, want %v", test.query, got, test.want)
+		}
+	}
+}
+
+func testImport(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	existing := newArticle(1)
+	create(t, repo, existing)
+
+	upsert := newArticle(2)
+	upsert.Slug = existing.Slug
+	duplicate := newArticle(3)
+	duplicate.Title = "title 4"
+	batch := []*entity.Article{upsert, newArticle(4), duplicate}
+
+	results, err := repo.Import(ctx, batch, article.ImportOptions{UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Updated || results[0].Err != nil {
+		t.Errorf("upsert result = %+v", results[0])
+	}
+	if results[1].Updated || results[1].Err != nil {
+		t.Errorf("insert result = %+v", results[1])
+	}
+	if !errors.Is(results[2].Err, article.ErrAlreadyExist) {
+		t.Errorf("duplicate result = %+v, want ErrAlreadyExist", results[2])
+	}
+
+	if a, err := repo.Detail(ctx, existing.ID); err != nil || a.Title != upsert.Title {
+		t.Errorf("upserted article = %+v, %v", a, err)
+	}
+	if a, err := repo.Detail(ctx, batch[1].ID); err != nil || a.Slug != batch[1].Slug {
+		t.Errorf("imported article = %+v, %v", a, err)
+	}
+}
+
+func testImportDuplicateSlugs(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	first, second := newArticle(1), newArticle(2)
+	second.Slug = first.Slug
+	results, err := repo.Import(ctx, []*entity.Article{first, second}, article.ImportOptions{UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Updated || results[0].Err != nil {
+		t.Errorf("superseded result = %+v", results[0])
+	}
+	if results[1].Updated || results[1].Err != nil {
+		t.Errorf("insert result = %+v", results[1])
+	}
+
+	articles, err := repo.List(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(articles) != 1 || articles[0].Title != second.Title {
+		t.Fatalf("articles = %+v, want only %q", articles, second.Title)
+	}
+	if first.ID != articles[0].ID {
+		t.Errorf("superseded article ID = %d, want %d", first.ID, articles[0].ID)
+	}
+}
+
+func testImportDryRun(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	create(t, repo, newArticle(1))
+	results, err := repo.Import(ctx, []*entity.Article{newArticle(2), newArticle(3)}, article.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, res := range results {
+		if res.Err != nil || res.Updated {
+			t.Errorf("dry run result %d = %+v", i, res)
+		}
+	}
+	if articles, _ := repo.List(ctx, 1); len(articles) != 1 {
+		t.Errorf("dry run wrote %d articles", len(articles)-1)
+	}
+}
+
+func testExport(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	var all []int64
+	for i := 0; i < 6; i++ {
+		a := newArticle(i)
+		if i%2 == 0 {
+			a.Status = entity.StatusDraft
+		}
+		all = append(all, create(t, repo, a))
+	}
+
+	tests := []struct {
+		name   string
+		filter article.ExportFilter
+		want   []int64
+	}{
+		{"all", article.ExportFilter{}, all},
+		{"status", article.ExportFilter{Status: entity.StatusDraft}, []int64{all[0], all[2], all[4]}},
+		{"tag", article.ExportFilter{Tag: "tag-3"}, []int64{all[3]}},
+		{"created", article.ExportFilter{CreatedFrom: newArticle(2).CreatedAt, CreatedTo: newArticle(4).CreatedAt}, all[2:5]},
+	}
+	for _, test := range tests {
+		var got []int64
+		err := repo.Export(ctx, test.filter, func(a *entity.Article) error {
+			got = append(got, a.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: exported %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := repo.Export(ctx, article.ExportFilter{}, func(*entity.Article) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("export after callback error = %v with %d calls", err, calls)
+	}
+}
+
+func testBatchDetail(t *testing.T, repo article.Article) {
+	first := create(t, repo, newArticle(1))
+	second := create(t, repo, newArticle(2))
+	articles, err := repo.BatchDetail(context.Background(), []int64{second, second + 1000, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(articles) != 3 || articles[0] == nil || articles[0].ID != second || articles[1] != nil || articles[2] == nil || articles[2].ID != first {
+		t.Errorf("batch detail = %+v", articles)
+	}
+}
+
+func testBatchCreateAtomic(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	results, err := repo.BatchCreate(ctx, []*entity.Article{newArticle(1), newArticle(2)})
+	if err != nil || !article.BatchApplied(results) {
+		t.Fatalf("batch create = %+v, %v", results, err)
+	}
+	for _, res := range results {
+		if _, err := repo.Detail(ctx, res.ID); err != nil {
+			t.Errorf("created article %d: %v", res.ID, err)
+		}
+	}
+
+	results, err = repo.BatchCreate(ctx, []*entity.Article{newArticle(3), newArticle(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results[0].Err, article.ErrBatchAborted) || !errors.Is(results[1].Err, article.ErrAlreadyExist) {
+		t.Errorf("rejected batch results = %+v", results)
+	}
+	if articles, _ := repo.List(ctx, 1); len(articles) != 2 {
+		t.Errorf("rejected batch left %d articles, want 2", len(articles))
+	}
+}
+
+func testBatchDeleteAtomic(t *testing.T, repo article.Article) {
+	ctx := context.Background()
+	first := create(t, repo, newArticle(1))
+	second := create(t, repo, newArticle(2))
+
+	results, err := repo.BatchDelete(ctx, []int64{first, second + 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results[0].Err, article.ErrBatchAborted) || !errors.Is(results[1].Err, article.ErrNotFound) {
+		t.Errorf("rejected batch results = %+v", results)
+	}
+	if _, err := repo.Detail(ctx, first); err != nil {
+		t.Errorf("rejected batch deleted %d: %v", first, err)
+	}
+
+	results, err = repo.BatchDelete(ctx, []int64{first, second})
+	if err != nil || !article.BatchApplied(results) {
+		t.Fatalf("batch delete = %+v, %v", results, err)
+	}
+	if articles, _ := repo.List(ctx, 1); len(articles) != 0 {
+		t.Errorf("%d articles left after deleting all", len(articles))
+	}
+}
+
+func testConcurrentCreate(t *testing.T, repo article.Article) {
+	const n = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created []int64
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := repo.Create(context.Background(), newArticle(i))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			created = append(created, id)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Slice(created, func(i, j int) bool { return created[i] < created[j] })
+	var listed []int64
+	for page := uint16(1); page <= n/PageSize; page++ {
+		articles, err := repo.List(context.Background(), page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, ids(articles)...)
+	}
+	if !reflect.DeepEqual(listed, created) {
+		t.Errorf("listed %v, created %v", listed, created)
+	}
+}
+
+func testConcurrentDuplicate(t *testing.T, repo article.Article) {
+	const n = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Create(context.Background(), newArticle(1))
+			if err != nil && !errors.Is(err, article.ErrAlreadyExist) {
+				t.Error(err)
+			}
+			mu.Lock()
+			if err == nil {
+				succeeded++
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if succeeded != 1 {
+		t.Errorf("%d concurrent creates with the same title succeeded, want 1", succeeded)
+	}
+}
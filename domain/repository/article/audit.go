@@ -0,0 +1,52 @@
+package article
+
+import (
+	"context"
+	"m1-article-service/domain/entity"
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/remoteaddr"
+	"m1-article-service/infrastructure/requestid"
+	"time"
+)
+
+// AuditLog is the append-only log of the writes of an Article repository.
+// Every row written, by any method, gets one entry in the transaction of the
+// write, attributed to the actor of its context, see ActorFromContext.
+type AuditLog interface {
+	// Audit returns a page of ten entries matching the filter, most recent
+	// first.
+	Audit(ctx context.Context, filter AuditFilter, page uint16) ([]*entity.AuditEntry, error)
+}
+
+// AuditFilter selects audit entries; zero fields match everything.
+type AuditFilter struct {
+	ArticleID int64
+	Principal string
+	// From and To bound the time of the entries, inclusive.
+	From time.Time
+	To   time.Time
+}
+
+// ActorFromContext returns the caller recorded in the request context.
+func ActorFromContext(ctx context.Context) entity.Actor {
+	return entity.Actor{
+		Principal: principal.FromContext(ctx),
+		RequestID: requestid.FromContext(ctx),
+		Peer:      remoteaddr.FromContext(ctx),
+	}
+}
+
+// AuditAction returns the action of a write that changed an article from
+// before to after, either being nil for creations and deletions.
+func AuditAction(before, after *entity.Article) string {
+	switch {
+	case before == nil:
+		return entity.AuditCreate
+	case after == nil:
+		return entity.AuditDelete
+	case before.Status != entity.StatusPublished && after.Status == entity.StatusPublished:
+		return entity.AuditPublish
+	default:
+		return entity.AuditUpdate
+	}
+}
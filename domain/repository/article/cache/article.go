@@ -0,0 +1,193 @@
+// Package cache provides a read-through caching decorator for the article
+// repository.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/sync/singleflight"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"strconv"
+	"time"
+)
+
+// Store is the key/value backend of the cache, see infrastructure/cache.
+// Values are encoded so that callers never share cached articles and
+// in-process and remote stores behave alike.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+const (
+	keyPrefix = "article:"
+	// listGenerationKey is bumped by every write; list pages are cached under
+	// the current generation, so a single increment invalidates all pages.
+	listGenerationKey = keyPrefix + "list:generation"
+	// loadTimeout bounds a shared load, which outlives the caller that
+	// started it so that one cancelled caller does not fail the others.
+	loadTimeout = 30 * time.Second
+)
+
+// ArticleRepository serves Detail and List from store and invalidates them
+// on writes. Concurrent misses for the same key share a single load. Store
+// failures are not fatal: reads fall back to the wrapped repository.
+//
+// A load racing with a write may cache the value read before the write, so
+// entries may be stale for at most ttl.
+type ArticleRepository struct {
+	next  article.Article
+	store Store
+	ttl   time.Duration
+	group *singleflight.Group
+}
+
+func NewArticleRepository(next article.Article, store Store, ttl time.Duration) *ArticleRepository {
+	return &ArticleRepository{next: next, store: store, ttl: ttl, group: &singleflight.Group{}}
+}
+
+func (r ArticleRepository) Detail(ctx context.Context, id int64) (*entity.Article, error) {
+	var a *entity.Article
+	err := r.readThrough(ctx, detailKey(id), &a, func(ctx context.Context) (any, error) {
+		return r.next.Detail(ctx, id)
+	})
+	return a, err
+}
+
+func (r ArticleRepository) List(ctx context.Context, page uint16) ([]*entity.Article, error) {
+	generation, _, err := r.store.Get(ctx, listGenerationKey)
+	if err != nil {
+		return r.next.List(ctx, page)
+	}
+	var articles []*entity.Article
+	key := fmt.Sprintf("%slist:%s:%d", keyPrefix, generation, page)
+	err = r.readThrough(ctx, key, &articles, func(ctx context.Context) (any, error) {
+		return r.next.List(ctx, page)
+	})
+	return articles, err
+}
+
+func (r ArticleRepository) Create(ctx context.Context, a *entity.Article) (int64, error) {
+	id, err := r.next.Create(ctx, a)
+	if err == nil {
+		r.invalidate(ctx)
+	}
+	return id, err
+}
+
+func (r ArticleRepository) Update(ctx context.Context, a *entity.Article) error {
+	err := r.next.Update(ctx, a)
+	if err == nil {
+		r.invalidate(ctx, a.ID)
+	}
+	return err
+}
+
+func (r ArticleRepository) Delete(ctx context.Context, id int64) error {
+	err := r.next.Delete(ctx, id)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+func (r ArticleRepository) Import(ctx context.Context, articles []*entity.Article, opts article.ImportOptions) ([]article.ImportResult, error) {
+	results, err := r.next.Import(ctx, articles, opts)
+	if err == nil && !opts.DryRun {
+		var updated []int64
+		for i, res := range results {
+			if res.Err == nil && res.Updated {
+				updated = append(updated, articles[i].ID)
+			}
+		}
+		r.invalidate(ctx, updated...)
+	}
+	return results, err
+}
+
+// Search is not cached: queries rarely repeat.
+func (r ArticleRepository) Search(ctx context.Context, query string, page uint16) ([]*entity.Article, error) {
+	return r.next.Search(ctx, query, page)
+}
+
+// Export always reads the wrapped repository to keep its snapshot.
+func (r ArticleRepository) Export(ctx context.Context, filter article.ExportFilter, fn func(*entity.Article) error) error {
+	return r.next.Export(ctx, filter, fn)
+}
+
+func (r ArticleRepository) BatchDetail(ctx context.Context, ids []int64) ([]*entity.Article, error) {
+	return r.next.BatchDetail(ctx, ids)
+}
+
+func (r ArticleRepository) BatchCreate(ctx context.Context, articles []*entity.Article) ([]article.BatchResult, error) {
+	results, err := r.next.BatchCreate(ctx, articles)
+	if err == nil && article.BatchApplied(results) {
+		r.invalidate(ctx)
+	}
+	return results, err
+}
+
+func (r ArticleRepository) BatchDelete(ctx context.Context, ids []int64) ([]article.BatchResult, error) {
+	results, err := r.next.BatchDelete(ctx, ids)
+	if err == nil && article.BatchApplied(results) {
+		r.invalidate(ctx, ids...)
+	}
+	return results, err
+}
+
+// readThrough decodes the entry under key into dst, loading and storing it
+// on a miss. Each caller waits for the shared load until its own ctx is
+// done.
+func (r ArticleRepository) readThrough(ctx context.Context, key string, dst any, load func(context.Context) (any, error)) error {
+	if data, ok, err := r.store.Get(ctx, key); err == nil && ok {
+		if json.Unmarshal(data, dst) == nil {
+			return nil
+		}
+	}
+
+	loaded := r.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		_ = r.store.Set(ctx, key, data, r.ttl)
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-loaded:
+		if result.Err != nil {
+			return result.Err
+		}
+		return json.Unmarshal(result.Val.([]byte), dst)
+	}
+}
+
+// invalidate drops the given articles and every cached list page. Errors
+// are ignored: the entries expire after ttl at the latest.
+func (r ArticleRepository) invalidate(ctx context.Context, ids ...int64) {
+	_, _ = r.store.Incr(ctx, listGenerationKey)
+	if len(ids) == 0 {
+		return
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = detailKey(id)
+	}
+	_ = r.store.Delete(ctx, keys...)
+}
+
+func detailKey(id int64) string {
+	return keyPrefix + "detail:" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	cacheInfra "m1-article-service/infrastructure/cache"
+	mock_article "m1-article-service/mock/repository"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestArticleRepository_Detail(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	repoMock.EXPECT().Detail(gomock.Any(), int64(1)).Return(&entity.Article{ID: 1, Title: "first"}, nil)
+	repoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	repoMock.EXPECT().Detail(gomock.Any(), int64(1)).Return(&entity.Article{ID: 1, Title: "second"}, nil)
+	repo := NewArticleRepository(repoMock, cacheInfra.NewLRU(10), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		a, err := repo.Detail(ctx, 1)
+		if err != nil || a.Title != "first" {
+			t.Fatalf("detail = %+v, %v", a, err)
+		}
+		// Mutating the result must not change the cached article.
+		a.Title = "changed"
+	}
+	if err := repo.Update(ctx, &entity.Article{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := repo.Detail(ctx, 1); a.Title != "second" {
+		t.Errorf("detail after update = %+v", a)
+	}
+}
+
+func TestArticleRepository_ListInvalidation(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	gomock.InOrder(
+		repoMock.EXPECT().List(gomock.Any(), uint16(1)).Return([]*entity.Article{{ID: 1}}, nil),
+		repoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(2), nil),
+		repoMock.EXPECT().List(gomock.Any(), uint16(1)).Return([]*entity.Article{{ID: 1}, {ID: 2}}, nil),
+		repoMock.EXPECT().BatchDelete(gomock.Any(), []int64{3}).Return([]article.BatchResult{{Err: article.ErrNotFound}}, nil),
+	)
+	repo := NewArticleRepository(repoMock, cacheInfra.NewLRU(10), time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if articles, _ := repo.List(ctx, 1); len(articles) != 1 {
+			t.Fatalf("list = %+v", articles)
+		}
+	}
+	if _, err := repo.Create(ctx, entity.NewArticle("title", "slug", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if articles, _ := repo.List(ctx, 1); len(articles) != 2 {
+		t.Fatalf("list after create = %+v", articles)
+	}
+	// A rejected batch writes nothing, so the cached page stays valid.
+	repo.BatchDelete(ctx, []int64{3})
+	if articles, _ := repo.List(ctx, 1); len(articles) != 2 {
+		t.Fatalf("list after rejected batch = %+v", articles)
+	}
+}
+
+func TestArticleRepository_Coalesces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	release := make(chan struct{})
+	repoMock.EXPECT().Detail(gomock.Any(), int64(1)).DoAndReturn(func(context.Context, int64) (*entity.Article, error) {
+		<-release
+		return &entity.Article{ID: 1}, nil
+	})
+	repo := NewArticleRepository(repoMock, cacheInfra.NewLRU(10), time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if a, err := repo.Detail(context.Background(), 1); err != nil || a.ID != 1 {
+				t.Errorf("detail = %+v, %v", a, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestArticleRepository_CoalescedCallerCancels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	release := make(chan struct{})
+	repoMock.EXPECT().Detail(gomock.Any(), int64(1)).DoAndReturn(func(ctx context.Context, _ int64) (*entity.Article, error) {
+		<-release
+		return &entity.Article{ID: 1}, ctx.Err()
+	})
+	repo := NewArticleRepository(repoMock, cacheInfra.NewLRU(10), time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := repo.Detail(ctx, 1)
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	second := make(chan error)
+	go func() {
+		a, err := repo.Detail(context.Background(), 1)
+		if err == nil && a.ID != 1 {
+			t.Errorf("detail = %+v", a)
+		}
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller error = %v, want the loaded article", err)
+	}
+}
@@ -0,0 +1,145 @@
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/infrastructure/metrics"
+	"time"
+)
+
+// ArticleRepository records latency metrics for every call to the wrapped
+// repository and counts successful mutations as article events; articles
+// created as published or published by an update also count as published.
+type ArticleRepository struct {
+	next    article.Article
+	metrics *metrics.Metrics
+}
+
+func NewArticleRepository(next article.Article, metrics *metrics.Metrics) *ArticleRepository {
+	return &ArticleRepository{next: next, metrics: metrics}
+}
+
+func (r ArticleRepository) Create(ctx context.Context, a *entity.Article) (id int64, err error) {
+	defer r.observe("create", time.Now(), &err)
+	if id, err = r.next.Create(ctx, a); err == nil {
+		r.metrics.ArticleEvents.WithLabelValues("created").Inc()
+		if a.Status == entity.StatusPublished {
+			r.metrics.ArticleEvents.WithLabelValues("published").Inc()
+		}
+	}
+	return id, err
+}
+
+func (r ArticleRepository) Update(ctx context.Context, a *entity.Article) (err error) {
+	defer r.observe("update", time.Now(), &err)
+	// Only a publishing update needs the stored status; a concurrent write
+	// may skew the count, which is acceptable for a metric.
+	wasPublished := true
+	if a.Status == entity.StatusPublished {
+		if before, err := r.next.Detail(ctx, a.ID); err == nil {
+			wasPublished = before.Status == entity.StatusPublished
+		}
+	}
+	if err = r.next.Update(ctx, a); err == nil {
+		r.metrics.ArticleEvents.WithLabelValues("updated").Inc()
+		if !wasPublished {
+			r.metrics.ArticleEvents.WithLabelValues("published").Inc()
+		}
+	}
+	return err
+}
+
+func (r ArticleRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+	if err = r.next.Delete(ctx, id); err == nil {
+		r.metrics.ArticleEvents.WithLabelValues("deleted").Inc()
+	}
+	return err
+}
+
+func (r ArticleRepository) Detail(ctx context.Context, id int64) (a *entity.Article, err error) {
+	defer r.observe("detail", time.Now(), &err)
+	return r.next.Detail(ctx, id)
+}
+
+func (r ArticleRepository) List(ctx context.Context, page uint16) (articles []*entity.Article, err error) {
+	defer r.observe("list", time.Now(), &err)
+	return r.next.List(ctx, page)
+}
+
+func (r ArticleRepository) Search(ctx context.Context, query string, page uint16) (articles []*entity.Article, err error) {
+	defer r.observe("search", time.Now(), &err)
+	return r.next.Search(ctx, query, page)
+}
+
+func (r ArticleRepository) Import(ctx context.Context, articles []*entity.Article, opts article.ImportOptions) (results []article.ImportResult, err error) {
+	defer r.observe("import", time.Now(), &err)
+	if results, err = r.next.Import(ctx, articles, opts); err == nil && !opts.DryRun {
+		for _, res := range results {
+			switch {
+			case res.Err != nil:
+			case res.Updated:
+				r.metrics.ArticleEvents.WithLabelValues("updated").Inc()
+			default:
+				r.metrics.ArticleEvents.WithLabelValues("created").Inc()
+			}
+			if res.Err == nil && res.Published {
+				r.metrics.ArticleEvents.WithLabelValues("published").Inc()
+			}
+		}
+	}
+	return results, err
+}
+
+func (r ArticleRepository) Export(ctx context.Context, filter article.ExportFilter, fn func(*entity.Article) error) (err error) {
+	defer r.observe("export", time.Now(), &err)
+	return r.next.Export(ctx, filter, fn)
+}
+
+func (r ArticleRepository) BatchDetail(ctx context.Context, ids []int64) (articles []*entity.Article, err error) {
+	defer r.observe("batch_detail", time.Now(), &err)
+	return r.next.BatchDetail(ctx, ids)
+}
+
+func (r ArticleRepository) BatchCreate(ctx context.Context, articles []*entity.Article) (results []article.BatchResult, err error) {
+	defer r.observe("batch_create", time.Now(), &err)
+	if results, err = r.next.BatchCreate(ctx, articles); err == nil && article.BatchApplied(results) {
+		r.metrics.ArticleEvents.WithLabelValues("created").Add(float64(len(results)))
+		for _, a := range articles {
+			if a.Status == entity.StatusPublished {
+				r.metrics.ArticleEvents.WithLabelValues("published").Inc()
+			}
+		}
+	}
+	return results, err
+}
+
+func (r ArticleRepository) BatchDelete(ctx context.Context, ids []int64) (results []article.BatchResult, err error) {
+	defer r.observe("batch_delete", time.Now(), &err)
+	if results, err = r.next.BatchDelete(ctx, ids); err == nil && article.BatchApplied(results) {
+		r.metrics.ArticleEvents.WithLabelValues("deleted").Add(float64(len(results)))
+	}
+	return results, err
+}
+
+func (r ArticleRepository) observe(operation string, start time.Time, err *error) {
+	r.metrics.QueryDuration.WithLabelValues(operation, result(*err)).Observe(time.Since(start).Seconds())
+}
+
+// result keeps the label cardinality bounded to the repository sentinels.
+func result(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, article.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, article.ErrAlreadyExist):
+		return "already_exist"
+	case errors.Is(err, article.ErrValidation):
+		return "validation"
+	default:
+		return "error"
+	}
+}
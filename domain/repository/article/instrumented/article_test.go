@@ -0,0 +1,77 @@
+package instrumented
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/infrastructure/metrics"
+	mock_article "m1-article-service/mock/repository"
+	"testing"
+)
+
+func TestArticleRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	repoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	repoMock.EXPECT().Delete(gomock.Any(), int64(2)).Return(article.ErrNotFound)
+	m := metrics.New()
+	repo := NewArticleRepository(repoMock, m)
+
+	if _, err := repo.Create(context.Background(), entity.NewArticle("title", "slug", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(context.Background(), 2); err != article.ErrNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	if v := testutil.ToFloat64(m.ArticleEvents.WithLabelValues("created")); v != 1 {
+		t.Errorf("created = %v, want 1", v)
+	}
+	if v := testutil.ToFloat64(m.ArticleEvents.WithLabelValues("deleted")); v != 0 {
+		t.Errorf("deleted = %v, want 0", v)
+	}
+	if n := testutil.CollectAndCount(m.QueryDuration); n != 2 {
+		t.Errorf("query duration series = %d, want 2", n)
+	}
+}
+
+func TestArticleRepository_Published(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repoMock := mock_article.NewMockArticle(ctrl)
+	repoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(2)
+	repoMock.EXPECT().Detail(gomock.Any(), int64(1)).Return(&entity.Article{ID: 1, Status: entity.StatusDraft}, nil)
+	repoMock.EXPECT().Detail(gomock.Any(), int64(2)).Return(&entity.Article{ID: 2, Status: entity.StatusPublished}, nil)
+	repoMock.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	repoMock.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).Return([]article.ImportResult{
+		{Updated: true, Published: true}, {Published: true}, {}, {Published: true, Err: article.ErrValidation},
+	}, nil)
+	m := metrics.New()
+	repo := NewArticleRepository(repoMock, m)
+	ctx := context.Background()
+
+	draft := entity.NewArticle("draft", "draft", nil)
+	draft.Status = entity.StatusDraft
+	for _, a := range []*entity.Article{entity.NewArticle("title", "slug", nil), draft} {
+		if _, err := repo.Create(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range []*entity.Article{
+		{ID: 1, Status: entity.StatusPublished}, // publishes the draft
+		{ID: 2, Status: entity.StatusPublished}, // already published
+		{ID: 3},                                 // keeps its status
+	} {
+		if err := repo.Update(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.Import(ctx, make([]*entity.Article, 4), article.ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := testutil.ToFloat64(m.ArticleEvents.WithLabelValues("published")); v != 4 {
+		t.Errorf("published = %v, want 4", v)
+	}
+}
@@ -0,0 +1,526 @@
+// Package memory provides an in-process implementation of the article
+// repository for tests and local demos.
+package memory
+
+import (
+	"context"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const pageSize = 10
+
+// ArticleRepository keeps articles in a map guarded by a mutex. IDs are
+// assigned from a sequence starting at 1 and never reused, titles are
+// unique, and stored articles are copied on the way in and out so callers
+// cannot mutate them. It is also the article.Outbox and article.Changes of
+// its own events and its own article.AuditLog.
+type ArticleRepository struct {
+	mu          sync.RWMutex
+	articles    map[int64]*entity.Article
+	titles      map[string]int64
+	lastID      int64
+	outbox      []*entity.Event
+	changes     []*entity.Event
+	lastEventID int64
+	// pruned is the last event removed from changes.
+	pruned      int64
+	audit       []*entity.AuditEntry
+	lastAuditID int64
+	// notified is the last event passed to the listeners.
+	notified     int64
+	listeners    map[int]func(*entity.Event)
+	nextListener int
+
+	// dispatchMu serialises Dispatch, which delivers without holding mu.
+	dispatchMu sync.Mutex
+}
+
+func NewArticleRepository() *ArticleRepository {
+	return &ArticleRepository{
+		articles:  map[int64]*entity.Article{},
+		titles:    map[string]int64{},
+		listeners: map[int]func(*entity.Event){},
+	}
+}
+
+func (r *ArticleRepository) Create(ctx context.Context, a *entity.Article) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.notify()
+	if err := r.insert(ctx, a); err != nil {
+		return 0, err
+	}
+	return a.ID, nil
+}
+
+func (r *ArticleRepository) Update(ctx context.Context, a *entity.Article) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.notify()
+	return r.update(ctx, a)
+}
+
+func (r *ArticleRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.notify()
+	return r.delete(ctx, id)
+}
+
+func (r *ArticleRepository) Detail(_ context.Context, id int64) (*entity.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.articles[id]
+	if !ok {
+		return nil, article.ErrNotFound
+	}
+	return clone(a), nil
+}
+
+// List returns the given 1-based page in ID order; page 0 is the first page.
+func (r *ArticleRepository) List(_ context.Context, page uint16) ([]*entity.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if page == 0 {
+		page = 1
+	}
+	ids := r.sortedIDs()
+	start := min(int(page-1)*pageSize, len(ids))
+	end := min(start+pageSize, len(ids))
+	articles := make([]*entity.Article, 0, end-start)
+	for _, id := range ids[start:end] {
+		articles = append(articles, clone(r.articles[id]))
+	}
+	return articles, nil
+}
+
+// Search matches whole words and orders the results by ID.
+func (r *ArticleRepository) Search(_ context.Context, query string, page uint16) ([]*entity.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	terms := words(query)
+	var matched []*entity.Article
+	for _, id := range r.sortedIDs() {
+		a := r.articles[id]
+		document := words(a.Title + " " + a.Slug + " " + strings.Join(a.Tags, " "))
+		if len(terms) > 0 && containsAll(document, terms) {
+			matched = append(matched, a)
+		}
+	}
+	start := min((int(max(page, 1))-1)*pageSize, len(matched))
+	end := min(start+pageSize, len(matched))
+	articles := make([]*entity.Article, 0, end-start)
+	for _, a := range matched[start:end] {
+		articles = append(articles, clone(a))
+	}
+	return articles, nil
+}
+
+func (r *ArticleRepository) Import(ctx context.Context, articles []*entity.Article, opts article.ImportOptions) ([]article.ImportResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.notify()
+	snapshot := r.snapshot()
+	existing := map[string]int64{}
+	if opts.UpsertBySlug {
+		existing = r.oldestBySlug()
+	}
+	results := make([]article.ImportResult, len(articles))
+	supersededBy := opts.SupersededBy(articles)
+	for i, a := range articles {
+		if supersededBy[i] >= 0 {
+			continue
+		}
+		if id, ok := existing[a.Slug]; ok {
+			a.ID = id
+			results[i].Updated = true
+			wasPublished := r.articles[id].Status == entity.StatusPublished
+			results[i].Err = r.update(ctx, a)
+			results[i].Published = results[i].Err == nil && !wasPublished && a.Status == entity.StatusPublished
+			continue
+		}
+		results[i].Err = r.insert(ctx, a)
+		results[i].Published = results[i].Err == nil && a.Status == entity.StatusPublished
+	}
+	article.ResolveSuperseded(articles, results, supersededBy)
+	if opts.DryRun {
+		r.restore(snapshot)
+	}
+	return results, nil
+}
+
+// Export iterates over a copy taken when it starts, so writes made by fn or
+// concurrently are not visible.
+func (r *ArticleRepository) Export(_ context.Context, filter article.ExportFilter, fn func(*entity.Article) error) error {
+	r.mu.RLock()
+	var matched []*entity.Article
+	for _, id := range r.sortedIDs() {
+		if a := r.articles[id]; matches(a, filter) {
+			matched = append(matched, clone(a))
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, a := range matched {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ArticleRepository) BatchDetail(_ context.Context, ids []int64) ([]*entity.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	articles := make([]*entity.Article, len(ids))
+	for i, id := range ids {
+		if a, ok := r.articles[id]; ok {
+			articles[i] = clone(a)
+		}
+	}
+	return articles, nil
+}
+
+func (r *ArticleRepository) BatchCreate(ctx context.Context, articles []*entity.Article) ([]article.BatchResult, error) {
+	return r.batch(len(articles), func(i int) (int64, error) {
+		err := r.insert(ctx, articles[i])
+		return articles[i].ID, err
+	}), nil
+}
+
+func (r *ArticleRepository) BatchDelete(ctx context.Context, ids []int64) ([]article.BatchResult, error) {
+	return r.batch(len(ids), func(i int) (int64, error) {
+		return ids[i], r.delete(ctx, ids[i])
+	}), nil
+}
+
+// batch applies every item and restores the previous state unless all of
+// them succeeded.
+func (r *ArticleRepository) batch(n int, apply func(int) (int64, error)) []article.BatchResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer r.notify()
+	snapshot := r.snapshot()
+	results := make([]article.BatchResult, n)
+	for i := range results {
+		results[i].ID, results[i].Err = apply(i)
+	}
+	if !article.BatchApplied(results) {
+		r.restore(snapshot)
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = article.ErrBatchAborted
+			}
+		}
+	}
+	return results
+}
+
+// Dispatch copies the pending events before delivering them so that writes
+// can go on meanwhile.
+func (r *ArticleRepository) Dispatch(_ context.Context, limit int, fn func(*entity.Event) error) (int, error) {
+	r.dispatchMu.Lock()
+	defer r.dispatchMu.Unlock()
+
+	r.mu.RLock()
+	pending := make([]*entity.Event, min(limit, len(r.outbox)))
+	for i := range pending {
+		pending[i] = cloneEvent(r.outbox[i])
+	}
+	r.mu.RUnlock()
+
+	delivered := 0
+	var err error
+	for _, e := range pending {
+		if err = fn(e); err != nil {
+			break
+		}
+		delivered++
+	}
+	r.mu.Lock()
+	// Only Dispatch removes events, so the delivered ones are still first.
+	r.outbox = slices.Clone(r.outbox[delivered:])
+	r.mu.Unlock()
+	return delivered, err
+}
+
+func (r *ArticleRepository) Since(_ context.Context, after int64, limit int) ([]*entity.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if after > 0 && after < r.pruned {
+		return nil, article.ErrPruned
+	}
+	start, _ := slices.BinarySearchFunc(r.changes, after+1, func(e *entity.Event, id int64) int {
+		return int(e.ID - id)
+	})
+	end := min(start+limit, len(r.changes))
+	events := make([]*entity.Event, 0, end-start)
+	for _, e := range r.changes[start:end] {
+		events = append(events, cloneEvent(e))
+	}
+	return events, nil
+}
+
+// Listen calls fn while a write holds the lock, so fn must not block or
+// use the repository.
+func (r *ArticleRepository) Listen(ctx context.Context, ready func(), fn func(*entity.Event)) error {
+	r.mu.Lock()
+	id := r.nextListener
+	r.nextListener++
+	r.listeners[id] = fn
+	r.mu.Unlock()
+	ready()
+
+	<-ctx.Done()
+	r.mu.Lock()
+	delete(r.listeners, id)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *ArticleRepository) Prune(_ context.Context, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keep := slices.IndexFunc(r.changes, func(e *entity.Event) bool { return !e.OccurredAt.Before(before) })
+	if keep < 0 {
+		keep = len(r.changes)
+	}
+	if keep > 0 {
+		r.pruned = r.changes[keep-1].ID
+	}
+	r.changes = slices.Clone(r.changes[keep:])
+	return nil
+}
+
+func (r *ArticleRepository) Audit(_ context.Context, filter article.AuditFilter, page uint16) ([]*entity.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var matched []*entity.AuditEntry
+	for i := len(r.audit) - 1; i >= 0; i-- {
+		e := r.audit[i]
+		switch {
+		case filter.ArticleID != 0 && e.ArticleID != filter.ArticleID,
+			filter.Principal != "" && e.Actor.Principal != filter.Principal,
+			!filter.From.IsZero() && e.OccurredAt.Before(filter.From),
+			!filter.To.IsZero() && e.OccurredAt.After(filter.To):
+			continue
+		}
+		matched = append(matched, e)
+	}
+	start := min((int(max(page, 1))-1)*pageSize, len(matched))
+	end := min(start+pageSize, len(matched))
+	entries := make([]*entity.AuditEntry, 0, end-start)
+	for _, e := range matched[start:end] {
+		entries = append(entries, cloneAuditEntry(e))
+	}
+	return entries, nil
+}
+
+// notify passes the events recorded by the write that just finished to the
+// listeners.
+func (r *ArticleRepository) notify() {
+	for _, e := range r.changes {
+		if e.ID <= r.notified {
+			continue
+		}
+		for _, fn := range r.listeners {
+			fn(cloneEvent(e))
+		}
+		r.notified = e.ID
+	}
+}
+
+func (r *ArticleRepository) insert(ctx context.Context, a *entity.Article) error {
+	if _, ok := r.titles[a.Title]; ok {
+		return article.ErrAlreadyExist
+	}
+	r.lastID++
+	a.ID = r.lastID
+	r.articles[a.ID] = clone(a)
+	r.titles[a.Title] = a.ID
+	r.record(entity.EventArticleCreated, a)
+	if a.Status == entity.StatusPublished {
+		r.record(entity.EventArticlePublished, a)
+	}
+	r.recordAudit(ctx, a.ID, nil, a)
+	return nil
+}
+
+// update replaces the title, slug, tags and, unless empty, the status of a
+// stored article.
+func (r *ArticleRepository) update(ctx context.Context, a *entity.Article) error {
+	stored, ok := r.articles[a.ID]
+	if !ok {
+		return article.ErrNotFound
+	}
+	if id, ok := r.titles[a.Title]; ok && id != a.ID {
+		return article.ErrAlreadyExist
+	}
+	updated := clone(stored)
+	updated.Title, updated.Slug, updated.Tags = a.Title, a.Slug, slices.Clone(a.Tags)
+	if a.Status != "" {
+		updated.Status = a.Status
+	}
+	delete(r.titles, stored.Title)
+	r.titles[updated.Title] = a.ID
+	r.articles[a.ID] = updated
+	r.record(entity.EventArticleUpdated, updated)
+	if stored.Status != entity.StatusPublished && updated.Status == entity.StatusPublished {
+		r.record(entity.EventArticlePublished, updated)
+	}
+	r.recordAudit(ctx, a.ID, stored, updated)
+	return nil
+}
+
+func (r *ArticleRepository) delete(ctx context.Context, id int64) error {
+	a, ok := r.articles[id]
+	if !ok {
+		return article.ErrNotFound
+	}
+	delete(r.articles, id)
+	delete(r.titles, a.Title)
+	r.record(entity.EventArticleDeleted, a)
+	r.recordAudit(ctx, id, a, nil)
+	return nil
+}
+
+// record appends an event about a to the outbox.
+func (r *ArticleRepository) record(eventType string, a *entity.Article) {
+	r.lastEventID++
+	e := &entity.Event{
+		ID:         r.lastEventID,
+		Type:       eventType,
+		ArticleID:  a.ID,
+		Article:    clone(a),
+		OccurredAt: time.Now(),
+	}
+	r.outbox = append(r.outbox, e)
+	r.changes = append(r.changes, e)
+}
+
+// recordAudit appends the audit entry of a write by the actor of ctx.
+func (r *ArticleRepository) recordAudit(ctx context.Context, id int64, before, after *entity.Article) {
+	r.lastAuditID++
+	e := &entity.AuditEntry{
+		ID:         r.lastAuditID,
+		Action:     article.AuditAction(before, after),
+		ArticleID:  id,
+		Actor:      article.ActorFromContext(ctx),
+		OccurredAt: time.Now(),
+	}
+	if before != nil {
+		e.Before = clone(before)
+	}
+	if after != nil {
+		e.After = clone(after)
+	}
+	r.audit = append(r.audit, e)
+}
+
+// oldestBySlug maps every stored slug to the ID of the oldest article using
+// it, as the Postgres implementation does.
+func (r *ArticleRepository) oldestBySlug() map[string]int64 {
+	oldest := make(map[string]int64, len(r.articles))
+	for id, a := range r.articles {
+		if current, ok := oldest[a.Slug]; !ok || id < current {
+			oldest[a.Slug] = id
+		}
+	}
+	return oldest
+}
+
+func (r *ArticleRepository) sortedIDs() []int64 {
+	ids := make([]int64, 0, len(r.articles))
+	for id := range r.articles {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type snapshot struct {
+	articles map[int64]*entity.Article
+	titles   map[string]int64
+	outbox   int
+	changes  int
+	audit    int
+}
+
+// snapshot is cheap because stored articles are never modified in place.
+func (r *ArticleRepository) snapshot() snapshot {
+	articles := make(map[int64]*entity.Article, len(r.articles))
+	for id, a := range r.articles {
+		articles[id] = a
+	}
+	titles := make(map[string]int64, len(r.titles))
+	for title, id := range r.titles {
+		titles[title] = id
+	}
+	return snapshot{articles: articles, titles: titles, outbox: len(r.outbox), changes: len(r.changes), audit: len(r.audit)}
+}
+
+// restore rolls back to s. Like a Postgres sequence, the IDs assigned in
+// the meantime are not reused.
+func (r *ArticleRepository) restore(s snapshot) {
+	r.articles, r.titles = s.articles, s.titles
+	r.outbox = r.outbox[:s.outbox]
+	r.changes = r.changes[:s.changes]
+	r.audit = r.audit[:s.audit]
+}
+
+func matches(a *entity.Article, filter article.ExportFilter) bool {
+	switch {
+	case filter.CreatedFrom != 0 && a.CreatedAt < filter.CreatedFrom,
+		filter.CreatedTo != 0 && a.CreatedAt > filter.CreatedTo,
+		filter.Tag != "" && !slices.Contains(a.Tags, filter.Tag),
+		filter.Status != "" && a.Status != filter.Status:
+		return false
+	}
+	return true
+}
+
+// words splits s into lower case words of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsAll(document, terms []string) bool {
+	for _, term := range terms {
+		if !slices.Contains(document, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func cloneEvent(e *entity.Event) *entity.Event {
+	c := *e
+	c.Article = clone(e.Article)
+	return &c
+}
+
+func cloneAuditEntry(e *entity.AuditEntry) *entity.AuditEntry {
+	c := *e
+	if e.Before != nil {
+		c.Before = clone(e.Before)
+	}
+	if e.After != nil {
+		c.After = clone(e.After)
+	}
+	return &c
+}
+
+func clone(a *entity.Article) *entity.Article {
+	c := *a
+	c.Tags = slices.Clone(a.Tags)
+	return &c
+}
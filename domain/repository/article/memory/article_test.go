@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/articletest"
+	"testing"
+)
+
+func TestArticleRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewArticleRepository()
+
+	id, err := repo.Create(ctx, entity.NewArticle("title", "slug", []string{"go"}))
+	if err != nil || id != 1 {
+		t.Fatalf("create = %d, %v", id, err)
+	}
+	if _, err := repo.Create(ctx, entity.NewArticle("title", "other", nil)); !errors.Is(err, article.ErrAlreadyExist) {
+		t.Errorf("duplicate title error = %v", err)
+	}
+
+	a, err := repo.Detail(ctx, id)
+	if err != nil || a.Title != "title" || a.Tags[0] != "go" {
+		t.Fatalf("detail = %+v, %v", a, err)
+	}
+	a.Tags[0] = "changed"
+	if stored, _ := repo.Detail(ctx, id); stored.Tags[0] != "go" {
+		t.Error("stored article was mutated through a returned copy")
+	}
+
+	if err := repo.Update(ctx, &entity.Article{ID: id, Title: "new", Slug: "slug"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, &entity.Article{ID: 42, Title: "x"}); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("update missing error = %v", err)
+	}
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Detail(ctx, id); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("detail after delete error = %v", err)
+	}
+	if err := repo.Delete(ctx, id); !errors.Is(err, article.ErrNotFound) {
+		t.Errorf("second delete error = %v", err)
+	}
+}
+
+func TestArticleRepository_BatchRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := NewArticleRepository()
+	repo.Create(ctx, entity.NewArticle("taken", "taken", nil))
+
+	results, err := repo.BatchCreate(ctx, []*entity.Article{
+		entity.NewArticle("fresh", "fresh", nil),
+		entity.NewArticle("taken", "taken", nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results[0].Err, article.ErrBatchAborted) || !errors.Is(results[1].Err, article.ErrAlreadyExist) {
+		t.Errorf("results = %+v", results)
+	}
+	if articles, _ := repo.List(ctx, 1); len(articles) != 1 {
+		t.Errorf("aborted batch left %d articles", len(articles))
+	}
+}
+
+func TestArticleRepository_ImportDryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := NewArticleRepository()
+	repo.Create(ctx, entity.NewArticle("old", "slug", nil))
+
+	results, err := repo.Import(ctx, []*entity.Article{
+		entity.NewArticle("updated", "slug", nil),
+		entity.NewArticle("created", "new", nil),
+	}, article.ImportOptions{DryRun: true, UpsertBySlug: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Updated || results[0].Err != nil || results[1].Updated || results[1].Err != nil {
+		t.Errorf("results = %+v", results)
+	}
+	articles, _ := repo.List(ctx, 1)
+	if len(articles) != 1 || articles[0].Title != "old" {
+		t.Errorf("dry run changed the store: %+v", articles)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	articletest.Run(t, func(t *testing.T) article.Article { return NewArticleRepository() })
+}
+
+func TestOutbox(t *testing.T) {
+	articletest.RunOutbox(t, func(t *testing.T) (article.Article, article.Outbox) {
+		repo := NewArticleRepository()
+		return repo, repo
+	})
+}
+
+func TestChanges(t *testing.T) {
+	articletest.RunChanges(t, func(t *testing.T) (article.Article, article.Changes) {
+		repo := NewArticleRepository()
+		return repo, repo
+	})
+}
+
+func TestAudit(t *testing.T) {
+	articletest.RunAudit(t, func(t *testing.T) (article.Article, article.AuditLog) {
+		repo := NewArticleRepository()
+		return repo, repo
+	})
+}
@@ -2,79 +2,92 @@ package pgx
 
 import (
 	"context"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"m1-article-service/domain/entity"
-	"m1-article-service/infrastructure/godotenv"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
 )
 
 const pageSize = 10
 
+// columns is the select list matching scanArticle.
+const columns = `id,title,slug,tags,created_at,status`
+
 type ArticleRepository struct {
-	env  *godotenv.Env
+	cfg  *config.Config
 	conn *pgxpool.Pool
 }
 
-func NewArticleRepository(env *godotenv.Env, conn *pgxpool.Pool) *ArticleRepository {
+func NewArticleRepository(cfg *config.Config, conn *pgxpool.Pool) *ArticleRepository {
 	lr := &ArticleRepository{
-		env:  env,
+		cfg:  cfg,
 		conn: conn,
 	}
 	return lr
 }
 
 func (r ArticleRepository) Create(ctx context.Context, article *entity.Article) (int64, error) {
-	sql := `INSERT INTO articles (title,slug,tags,created_at) VALUES($1,$2,$3,$4) RETURNING id`
-	err := r.conn.
-		QueryRow(ctx, sql,
-			article.Title, article.Slug, article.Tags, article.CreatedAt).Scan(&article.ID)
+	sql := `INSERT INTO articles (title,slug,tags,created_at,status) VALUES($1,$2,$3,$4,$5) RETURNING id`
+	err := r.inTx(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, sql,
+			article.Title, article.Slug, article.Tags, article.CreatedAt, article.Status).Scan(&article.ID)
+	})
 	if err != nil {
-		return 0, err
+		return 0, mapError(err)
 	}
-	return article.ID, err
+	return article.ID, nil
 }
 
 func (r ArticleRepository) Update(ctx context.Context, article *entity.Article) error {
-	if _, err := r.conn.Exec(ctx, `UPDATE articles SET title=$1,slug=$2,tags=$3 WHERE id=$4`,
-		article.Title, article.Slug, article.Tags, article.ID); err != nil {
-		return err
-	}
-	return nil
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		return affectedOne(tx.Exec(ctx, `UPDATE articles SET title=$1,slug=$2,tags=$3,status=COALESCE(NULLIF($5,''),status) WHERE id=$4`,
+			article.Title, article.Slug, article.Tags, article.ID, article.Status))
+	})
 }
 
 func (r ArticleRepository) Delete(ctx context.Context, id int64) error {
-	if _, err := r.conn.Exec(ctx, `DELETE FROM articles WHERE id=$1`, id); err != nil {
-		return err
-	}
-	return nil
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		return affectedOne(tx.Exec(ctx, `DELETE FROM articles WHERE id=$1`, id))
+	})
 }
 
 func (r ArticleRepository) Detail(ctx context.Context, id int64) (article *entity.Article, err error) {
 	article = new(entity.Article)
-	err = r.conn.QueryRow(ctx, `SELECT * FROM articles WHERE id=$1`, id).
-		Scan(&article.ID, &article.Title, &article.Slug, &article.Tags, &article.CreatedAt)
+	err = database.RetryRead(ctx, r.cfg.DatabaseReadRetries, func(ctx context.Context) error {
+		return scanArticle(r.conn.QueryRow(ctx, `SELECT `+columns+` FROM articles WHERE id=$1`, id), article)
+	})
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return
 }
 
-func (r ArticleRepository) List(ctx context.Context, pageNumber uint16) ([]*entity.Article, error) {
-	articles := make([]*entity.Article, 0)
-	offset := (pageNumber - 1) * pageSize
-	rows, err := r.conn.Query(ctx, `SELECT * FROM articles LIMIT $1 OFFSET $2 `, pageSize, offset)
-	if err != nil {
-		return nil, err
-	}
-	for rows.Next() {
-		article := &entity.Article{}
-		if err := rows.Scan(&article.ID, &article.Title, &article.Slug, &article.Tags, &article.CreatedAt); err != nil {
-			return nil, err
+// List returns the given 1-based page in ID order; page 0 is the first page.
+func (r ArticleRepository) List(ctx context.Context, pageNumber uint16) (articles []*entity.Article, err error) {
+	offset := (int(max(pageNumber, 1)) - 1) * pageSize
+	err = database.RetryRead(ctx, r.cfg.DatabaseReadRetries, func(ctx context.Context) error {
+		articles = make([]*entity.Article, 0)
+		rows, err := r.conn.Query(ctx, `SELECT `+columns+` FROM articles ORDER BY id LIMIT $1 OFFSET $2`, pageSize, offset)
+		if err != nil {
+			return err
 		}
-		articles = append(articles, article)
-	}
-
-	if rows.Err() != nil {
+		defer rows.Close()
+		for rows.Next() {
+			article := &entity.Article{}
+			if err := scanArticle(rows, article); err != nil {
+				return err
+			}
+			articles = append(articles, article)
+		}
+		return rows.Err()
+	})
+	if err != nil {
 		return nil, err
 	}
 	return articles, nil
 }
+
+func scanArticle(row pgx.Row, article *entity.Article) error {
+	return row.Scan(&article.ID, &article.Title, &article.Slug, &article.Tags, &article.CreatedAt, &article.Status)
+}
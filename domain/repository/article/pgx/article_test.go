@@ -0,0 +1,77 @@
+package pgx
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/articletest"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/migrator"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestConformance runs against the database in TEST_DATABASE_URL, whose
+// tables are truncated before every test.
+func TestConformance(t *testing.T) {
+	pool := openPool(t)
+	articletest.Run(t, func(t *testing.T) article.Article {
+		truncate(t, pool)
+		return NewArticleRepository(&config.Config{}, pool)
+	})
+}
+
+func TestOutbox(t *testing.T) {
+	pool := openPool(t)
+	articletest.RunOutbox(t, func(t *testing.T) (article.Article, article.Outbox) {
+		truncate(t, pool)
+		return NewArticleRepository(&config.Config{}, pool), NewOutbox(pool)
+	})
+}
+
+func TestChanges(t *testing.T) {
+	pool := openPool(t)
+	articletest.RunChanges(t, func(t *testing.T) (article.Article, article.Changes) {
+		truncate(t, pool)
+		return NewArticleRepository(&config.Config{}, pool), NewChanges(pool)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	pool := openPool(t)
+	articletest.RunAudit(t, func(t *testing.T) (article.Article, article.AuditLog) {
+		truncate(t, pool)
+		return NewArticleRepository(&config.Config{}, pool), NewAuditLog(pool)
+	})
+}
+
+func truncate(t *testing.T, pool *pgxpool.Pool) {
+	if _, err := pool.Exec(context.Background(), `TRUNCATE articles, outbox, article_changes, article_changes_horizon, audit_log RESTART IDENTITY`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// openPool connects to TEST_DATABASE_URL after migrating it, or skips the
+// test when it is not set.
+func openPool(t *testing.T) *pgxpool.Pool {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	m, err := migrator.New(dsn, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
@@ -0,0 +1,77 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"strings"
+)
+
+// AuditLog reads the audit_log table filled by the article_audit trigger.
+type AuditLog struct {
+	conn *pgxpool.Pool
+}
+
+func NewAuditLog(conn *pgxpool.Pool) *AuditLog {
+	return &AuditLog{conn: conn}
+}
+
+func (l AuditLog) Audit(ctx context.Context, filter article.AuditFilter, page uint16) ([]*entity.AuditEntry, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ArticleID != 0 {
+		where("article_id = $%d", filter.ArticleID)
+	}
+	if filter.Principal != "" {
+		where("principal = $%d", filter.Principal)
+	}
+	if !filter.From.IsZero() {
+		where("occurred_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("occurred_at <= $%d", filter.To)
+	}
+
+	query := `SELECT id,action,article_id,principal,request_id,peer,before,after,occurred_at FROM audit_log`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT %d OFFSET %d`, pageSize, (int(max(page, 1))-1)*pageSize)
+	rows, err := l.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entity.AuditEntry, error) {
+		e := &entity.AuditEntry{}
+		return e, row.Scan(&e.ID, &e.Action, &e.ArticleID, &e.Actor.Principal, &e.Actor.RequestID, &e.Actor.Peer,
+			&e.Before, &e.After, &e.OccurredAt)
+	})
+}
+
+// inTx runs fn in a transaction attributed to the actor of ctx.
+func (r ArticleRepository) inTx(ctx context.Context, fn func(pgx.Tx) error) error {
+	return pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		if err := setActor(ctx, tx); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// setActor attributes the writes of tx to the actor of ctx in the entries
+// of the article_audit trigger.
+func setActor(ctx context.Context, tx pgx.Tx) error {
+	actor := article.ActorFromContext(ctx)
+	_, err := tx.Exec(ctx, `SELECT set_config('audit.principal', $1, true), set_config('audit.request_id', $2, true), set_config('audit.peer', $3, true)`,
+		actor.Principal, actor.RequestID, actor.Peer)
+	return err
+}
@@ -0,0 +1,84 @@
+package pgx
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/infrastructure/database"
+)
+
+func (r ArticleRepository) BatchDetail(ctx context.Context, ids []int64) (articles []*entity.Article, err error) {
+	err = database.RetryRead(ctx, r.cfg.DatabaseReadRetries, func(ctx context.Context) error {
+		rows, err := r.conn.Query(ctx, `SELECT `+columns+` FROM articles WHERE id = ANY($1)`, ids)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		byID := make(map[int64]*entity.Article, len(ids))
+		for rows.Next() {
+			a := &entity.Article{}
+			if err := scanArticle(rows, a); err != nil {
+				return err
+			}
+			byID[a.ID] = a
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		articles = make([]*entity.Article, len(ids))
+		for i, id := range ids {
+			articles[i] = byID[id]
+		}
+		return nil
+	})
+	return articles, err
+}
+
+func (r ArticleRepository) BatchCreate(ctx context.Context, articles []*entity.Article) ([]article.BatchResult, error) {
+	return r.batch(ctx, len(articles), func(ctx context.Context, tx pgx.Tx, i int) (int64, error) {
+		a := articles[i]
+		err := tx.QueryRow(ctx, `INSERT INTO articles (title,slug,tags,created_at,status) VALUES($1,$2,$3,$4,$5) RETURNING id`,
+			a.Title, a.Slug, a.Tags, a.CreatedAt, a.Status).Scan(&a.ID)
+		return a.ID, mapError(err)
+	})
+}
+
+func (r ArticleRepository) BatchDelete(ctx context.Context, ids []int64) ([]article.BatchResult, error) {
+	return r.batch(ctx, len(ids), func(ctx context.Context, tx pgx.Tx, i int) (int64, error) {
+		return ids[i], affectedOne(tx.Exec(ctx, `DELETE FROM articles WHERE id=$1`, ids[i]))
+	})
+}
+
+// batch runs apply for n items in one transaction, each in a savepoint so
+// that every failing item is reported, and commits only if all succeeded.
+func (r ArticleRepository) batch(ctx context.Context, n int, apply func(context.Context, pgx.Tx, int) (int64, error)) ([]article.BatchResult, error) {
+	results := make([]article.BatchResult, n)
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	if err := setActor(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	failed := false
+	for i := range results {
+		results[i].Err = inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+			var err error
+			results[i].ID, err = apply(ctx, tx, i)
+			return err
+		})
+		failed = failed || results[i].Err != nil
+	}
+	if failed {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = article.ErrBatchAborted
+			}
+		}
+		return results, nil
+	}
+	return results, tx.Commit(ctx)
+}
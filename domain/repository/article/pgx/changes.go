@@ -0,0 +1,114 @@
+package pgx
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"time"
+)
+
+// changesChannel is notified by the outbox_article_change trigger when a
+// transaction recording events commits.
+const changesChannel = "article_events"
+
+// listenPageSize is how many events Listen reads at once after a
+// notification.
+const listenPageSize = 100
+
+// Changes reads the article_changes table and listens to its notifications.
+// The changes are numbered by the sequence_article_changes function, which
+// Since and Listen call before reading.
+type Changes struct {
+	conn *pgxpool.Pool
+}
+
+func NewChanges(conn *pgxpool.Pool) *Changes {
+	return &Changes{conn: conn}
+}
+
+// Since reads the horizon after the events, so that it sees any pruning
+// that removed events before they were read.
+func (c Changes) Since(ctx context.Context, after int64, limit int) ([]*entity.Event, error) {
+	events, err := c.since(ctx, after, limit)
+	if err != nil || after == 0 {
+		return events, err
+	}
+	var pruned int64
+	if err := c.conn.QueryRow(ctx, `SELECT coalesce(max(seq),0) FROM article_changes_horizon`).Scan(&pruned); err != nil {
+		return nil, err
+	}
+	if after < pruned {
+		return nil, article.ErrPruned
+	}
+	return events, nil
+}
+
+func (c Changes) since(ctx context.Context, after int64, limit int) ([]*entity.Event, error) {
+	if _, err := c.conn.Exec(ctx, `SELECT sequence_article_changes()`); err != nil {
+		return nil, err
+	}
+	rows, err := c.conn.Query(ctx, `SELECT seq,type,article_id,payload,occurred_at FROM article_changes WHERE seq > $1 ORDER BY seq LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanEvent)
+}
+
+// Listen holds a connection of the pool for as long as it runs and closes it
+// afterwards rather than returning it with a LISTEN in place. After every
+// notification it passes on the events numbered since the last one it
+// passed, starting from those numbered when it started.
+func (c Changes) Listen(ctx context.Context, ready func(), fn func(*entity.Event)) error {
+	var last int64
+	if err := c.conn.QueryRow(ctx, `SELECT coalesce(max(seq),0) FROM article_changes`).Scan(&last); err != nil {
+		return err
+	}
+	conn, err := c.conn.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if _, err := pgConn.Exec(ctx, `LISTEN `+changesChannel); err != nil {
+		return err
+	}
+	ready()
+	for {
+		if _, err := pgConn.WaitForNotification(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for {
+			events, err := c.since(ctx, last, listenPageSize)
+			if err != nil {
+				return err
+			}
+			for _, e := range events {
+				fn(e)
+				last = e.ID
+			}
+			if len(events) < listenPageSize {
+				break
+			}
+		}
+	}
+}
+
+// Prune moves the horizon up to the last pruned event in the same statement.
+// Changes not numbered yet are kept until they are.
+func (c Changes) Prune(ctx context.Context, before time.Time) error {
+	_, err := c.conn.Exec(ctx, `WITH pruned AS (DELETE FROM article_changes WHERE occurred_at < $1 AND seq IS NOT NULL RETURNING seq)
+		INSERT INTO article_changes_horizon (id, seq) SELECT true, max(seq) FROM pruned HAVING count(*) > 0
+		ON CONFLICT (id) DO UPDATE SET seq = greatest(article_changes_horizon.seq, excluded.seq)`, before)
+	return err
+}
+
+func scanEvent(row pgx.CollectableRow) (*entity.Event, error) {
+	e := &entity.Event{}
+	return e, row.Scan(&e.ID, &e.Type, &e.ArticleID, &e.Article, &e.OccurredAt)
+}
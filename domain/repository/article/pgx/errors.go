@@ -0,0 +1,42 @@
+package pgx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"m1-article-service/domain/repository/article"
+)
+
+// mapError translates Postgres errors to the sentinels of the article
+// package; other errors are returned unchanged.
+func mapError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return article.ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == "23505": // unique_violation
+		return fmt.Errorf("%w: %s", article.ErrAlreadyExist, pgErr.Detail)
+	case pgErr.Code == "22001", // string_data_right_truncation
+		pgErr.Code == "23502", // not_null_violation
+		pgErr.Code == "23514": // check_violation
+		return fmt.Errorf("%w: %s", article.ErrValidation, pgErr.Message)
+	default:
+		return err
+	}
+}
+
+// affectedOne maps a write that matched no row to article.ErrNotFound.
+func affectedOne(tag pgconn.CommandTag, err error) error {
+	if err != nil {
+		return mapError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return article.ErrNotFound
+	}
+	return nil
+}
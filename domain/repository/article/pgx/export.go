@@ -0,0 +1,70 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"strings"
+)
+
+// Export decodes rows as they arrive from the connection, so memory stays
+// bounded, inside a read-only repeatable read transaction so that the whole
+// export sees one snapshot however long it takes.
+func (r ArticleRepository) Export(ctx context.Context, filter article.ExportFilter, fn func(*entity.Article) error) error {
+	tx, err := r.conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args := exportQuery(filter)
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a := &entity.Article{}
+		if err := scanArticle(rows, a); err != nil {
+			return err
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func exportQuery(filter article.ExportFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.CreatedFrom != 0 {
+		where("created_at >= $%d", filter.CreatedFrom)
+	}
+	if filter.CreatedTo != 0 {
+		where("created_at <= $%d", filter.CreatedTo)
+	}
+	if filter.Tag != "" {
+		where("$%d = ANY(tags)", filter.Tag)
+	}
+	if filter.Status != "" {
+		where("status = $%d", filter.Status)
+	}
+
+	query := `SELECT ` + columns + ` FROM articles`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	return query + ` ORDER BY id`, args
+}
@@ -0,0 +1,124 @@
+package pgx
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+)
+
+var importColumns = []string{"title", "slug", "tags", "created_at", "status"}
+
+// Import copies new articles with CopyFrom. If the copy fails, the batch is
+// written row by row, each in its own savepoint, to report which rows are
+// at fault.
+func (r ArticleRepository) Import(ctx context.Context, articles []*entity.Article, opts article.ImportOptions) ([]article.ImportResult, error) {
+	results := make([]article.ImportResult, len(articles))
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	if err := setActor(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	inserts := make([]int, 0, len(articles))
+	supersededBy := opts.SupersededBy(articles)
+	existing := map[string]int64{}
+	if opts.UpsertBySlug {
+		if existing, err = existingSlugs(ctx, tx, articles); err != nil {
+			return nil, err
+		}
+	}
+	for i, a := range articles {
+		if supersededBy[i] >= 0 {
+			continue
+		}
+		id, ok := existing[a.Slug]
+		if !ok {
+			inserts = append(inserts, i)
+			continue
+		}
+		a.ID = id
+		results[i].Updated = true
+		results[i].Err = inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+			// The joined row holds the status from before the update.
+			var before string
+			err := tx.QueryRow(ctx, `UPDATE articles a SET title=$1,tags=$2,status=$3
+				FROM (SELECT id, status FROM articles WHERE id=$4 FOR UPDATE) old
+				WHERE a.id=old.id RETURNING old.status`, a.Title, a.Tags, a.Status, a.ID).Scan(&before)
+			if err != nil {
+				return mapError(err)
+			}
+			results[i].Published = before != entity.StatusPublished && a.Status == entity.StatusPublished
+			return nil
+		})
+	}
+
+	err = inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"articles"}, importColumns,
+			pgx.CopyFromSlice(len(inserts), func(i int) ([]any, error) {
+				a := articles[inserts[i]]
+				return []any{a.Title, a.Slug, a.Tags, a.CreatedAt, a.Status}, nil
+			}))
+		return err
+	})
+	if err == nil {
+		for _, i := range inserts {
+			results[i].Published = articles[i].Status == entity.StatusPublished
+		}
+	} else {
+		for _, i := range inserts {
+			a := articles[i]
+			results[i].Err = inSavepoint(ctx, tx, func(tx pgx.Tx) error {
+				return mapError(tx.QueryRow(ctx, `INSERT INTO articles (title,slug,tags,created_at,status) VALUES($1,$2,$3,$4,$5) RETURNING id`,
+					a.Title, a.Slug, a.Tags, a.CreatedAt, a.Status).Scan(&a.ID))
+			})
+			results[i].Published = results[i].Err == nil && a.Status == entity.StatusPublished
+		}
+	}
+
+	article.ResolveSuperseded(articles, results, supersededBy)
+	if opts.DryRun {
+		return results, nil
+	}
+	return results, tx.Commit(ctx)
+}
+
+// existingSlugs maps the slugs of articles that are already stored to the
+// ID of the oldest article using them.
+func existingSlugs(ctx context.Context, tx pgx.Tx, articles []*entity.Article) (map[string]int64, error) {
+	slugs := make([]string, len(articles))
+	for i, a := range articles {
+		slugs[i] = a.Slug
+	}
+	rows, err := tx.Query(ctx, `SELECT DISTINCT ON (slug) slug, id FROM articles WHERE slug = ANY($1) ORDER BY slug, id`, slugs)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]int64)
+	var (
+		slug string
+		id   int64
+	)
+	_, err = pgx.ForEachRow(rows, []any{&slug, &id}, func() error {
+		existing[slug] = id
+		return nil
+	})
+	return existing, err
+}
+
+// inSavepoint runs fn in a nested transaction so that its failure does not
+// abort tx.
+func inSavepoint(ctx context.Context, tx pgx.Tx, fn func(tx pgx.Tx) error) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(sp); err != nil {
+		_ = sp.Rollback(ctx)
+		return err
+	}
+	return sp.Commit(ctx)
+}
@@ -0,0 +1,87 @@
+package pgx
+
+import (
+	"cmp"
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/entity"
+	"slices"
+	"time"
+)
+
+// dispatchLease bounds a Dispatch call: its batch is claimed for that long
+// and no event is passed to fn once it is over.
+const dispatchLease = time.Minute
+
+// Outbox reads the outbox table filled by the articles_outbox trigger.
+type Outbox struct {
+	conn *pgxpool.Pool
+}
+
+func NewOutbox(conn *pgxpool.Pool) *Outbox {
+	return &Outbox{conn: conn}
+}
+
+// Dispatch claims a batch and delivers it outside of any transaction. The
+// relays of other replicas find nothing to deliver while the batch is
+// claimed, so events leave in order one batch at a time. The batch of a
+// relay that dies is delivered again once its claim expires.
+func (o Outbox) Dispatch(ctx context.Context, limit int, fn func(*entity.Event) error) (int, error) {
+	deadline := time.Now().Add(dispatchLease)
+	events, err := o.claim(ctx, limit)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	claimed := make([]int64, len(events))
+	delivered := make([]int64, 0, len(events))
+	var deliverErr error
+	for i, e := range events {
+		claimed[i] = e.ID
+		if deliverErr != nil || time.Now().After(deadline) {
+			continue
+		}
+		if deliverErr = fn(e); deliverErr == nil {
+			delivered = append(delivered, e.ID)
+		}
+	}
+	err = pgx.BeginFunc(ctx, o.conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, delivered); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `UPDATE outbox SET claimed_until=NULL WHERE id = ANY($1)`, claimed)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(delivered), deliverErr
+}
+
+// claim returns the first events in line, claimed for dispatchLease, or none
+// while a batch claimed by another call has not expired. Events are only in
+// line once every transaction older than theirs has ended, so that one
+// committing late is not overtaken by events recorded after it; a long
+// writing transaction holds back the events of those started after it.
+func (o Outbox) claim(ctx context.Context, limit int) ([]*entity.Event, error) {
+	var events []*entity.Event
+	err := pgx.BeginFunc(ctx, o.conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('article_outbox'))`); err != nil {
+			return err
+		}
+		rows, err := tx.Query(ctx, `UPDATE outbox SET claimed_until = now() + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT id FROM outbox WHERE xact_id < pg_snapshot_xmin(pg_current_snapshot()) ORDER BY id LIMIT $1
+			)
+			AND NOT EXISTS (SELECT 1 FROM outbox WHERE claimed_until > now())
+			RETURNING id,type,article_id,payload,occurred_at`, limit, dispatchLease.Seconds())
+		if err != nil {
+			return err
+		}
+		events, err = pgx.CollectRows(rows, scanEvent)
+		return err
+	})
+	slices.SortFunc(events, func(a, b *entity.Event) int { return cmp.Compare(a.ID, b.ID) })
+	return events, err
+}
@@ -0,0 +1,35 @@
+package pgx
+
+import (
+	"context"
+	"m1-article-service/domain/entity"
+	"m1-article-service/infrastructure/database"
+)
+
+// Search matches query against the indexed search column, generated from
+// the title, slug and tags.
+func (r ArticleRepository) Search(ctx context.Context, query string, pageNumber uint16) (articles []*entity.Article, err error) {
+	offset := (int(max(pageNumber, 1)) - 1) * pageSize
+	err = database.RetryRead(ctx, r.cfg.DatabaseReadRetries, func(ctx context.Context) error {
+		articles = make([]*entity.Article, 0)
+		rows, err := r.conn.Query(ctx, `SELECT `+columns+` FROM articles, plainto_tsquery('simple', $1) query
+			WHERE search @@ query
+			ORDER BY ts_rank(search, query) DESC, id LIMIT $2 OFFSET $3`, query, pageSize, offset)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			a := &entity.Article{}
+			if err := scanArticle(rows, a); err != nil {
+				return err
+			}
+			articles = append(articles, a)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
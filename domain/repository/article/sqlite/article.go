@@ -0,0 +1,364 @@
+// Package sqlite stores articles in a SQLite database through the pure-Go
+// modernc.org/sqlite driver. Tags live in the article_tags join table and
+// articles_fts is an FTS5 index maintained by triggers, see
+// domain/entity/migration/sqlite.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const pageSize = 10
+
+// columns is the select list matching scanArticle; tags are aggregated into
+// a JSON array in their original order.
+const columns = `articles.id, articles.title, articles.slug, articles.created_at, articles.status,
+	(SELECT json_group_array(tag ORDER BY position) FROM article_tags WHERE article_id = articles.id)`
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type ArticleRepository struct {
+	db *sql.DB
+}
+
+func NewArticleRepository(db *sql.DB) *ArticleRepository {
+	return &ArticleRepository{db: db}
+}
+
+func (r ArticleRepository) Create(ctx context.Context, a *entity.Article) (int64, error) {
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		return insert(ctx, tx, a)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return a.ID, nil
+}
+
+func (r ArticleRepository) Update(ctx context.Context, a *entity.Article) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		before, err := load(ctx, tx, a.ID)
+		if err != nil {
+			return err
+		}
+		status := a.Status
+		if status == "" {
+			status = before.Status
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE articles SET title = ?, slug = ?, status = ? WHERE id = ?`, a.Title, a.Slug, status, a.ID); err != nil {
+			return mapError(err)
+		}
+		if err := replaceTags(ctx, tx, a.ID, a.Tags); err != nil {
+			return err
+		}
+		events := []string{entity.EventArticleUpdated}
+		if before.Status != entity.StatusPublished && status == entity.StatusPublished {
+			events = append(events, entity.EventArticlePublished)
+		}
+		return recordWrite(ctx, tx, a.ID, before, events...)
+	})
+}
+
+func (r ArticleRepository) Delete(ctx context.Context, id int64) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return remove(ctx, tx, id)
+	})
+}
+
+func (r ArticleRepository) Detail(ctx context.Context, id int64) (*entity.Article, error) {
+	a := &entity.Article{}
+	if err := scanArticle(r.db.QueryRowContext(ctx, `SELECT `+columns+` FROM articles WHERE id = ?`, id), a); err != nil {
+		return nil, mapError(err)
+	}
+	return a, nil
+}
+
+// List returns the given 1-based page in ID order; page 0 is the first page.
+func (r ArticleRepository) List(ctx context.Context, page uint16) ([]*entity.Article, error) {
+	return query(ctx, r.db, `SELECT `+columns+` FROM articles ORDER BY id LIMIT ? OFFSET ?`, pageSize, offset(page))
+}
+
+// Search matches every word of q against the FTS5 index, ranked by bm25.
+func (r ArticleRepository) Search(ctx context.Context, q string, page uint16) ([]*entity.Article, error) {
+	terms := words(q)
+	if len(terms) == 0 {
+		return []*entity.Article{}, nil
+	}
+	// Quoting makes every word a plain string instead of FTS5 syntax;
+	// space separated strings must all match.
+	match := `"` + strings.Join(terms, `" "`) + `"`
+	return query(ctx, r.db, `SELECT `+columns+` FROM articles JOIN articles_fts ON articles_fts.rowid = articles.id
+		WHERE articles_fts MATCH ? ORDER BY bm25(articles_fts), articles.id LIMIT ? OFFSET ?`, match, pageSize, offset(page))
+}
+
+func (r ArticleRepository) BatchDetail(ctx context.Context, ids []int64) ([]*entity.Article, error) {
+	found, err := query(ctx, r.db, `SELECT `+columns+` FROM articles WHERE id IN (SELECT value FROM json_each(?))`, jsonArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*entity.Article, len(found))
+	for _, a := range found {
+		byID[a.ID] = a
+	}
+	articles := make([]*entity.Article, len(ids))
+	for i, id := range ids {
+		articles[i] = byID[id]
+	}
+	return articles, nil
+}
+
+func (r ArticleRepository) BatchCreate(ctx context.Context, articles []*entity.Article) ([]article.BatchResult, error) {
+	return r.batch(ctx, len(articles), func(tx *sql.Tx, i int) (int64, error) {
+		err := insert(ctx, tx, articles[i])
+		return articles[i].ID, err
+	})
+}
+
+func (r ArticleRepository) BatchDelete(ctx context.Context, ids []int64) ([]article.BatchResult, error) {
+	return r.batch(ctx, len(ids), func(tx *sql.Tx, i int) (int64, error) {
+		return ids[i], remove(ctx, tx, ids[i])
+	})
+}
+
+// batch applies n items in one transaction, each in a savepoint so that
+// every failing item is reported, and commits only if all succeeded.
+func (r ArticleRepository) batch(ctx context.Context, n int, apply func(*sql.Tx, int) (int64, error)) ([]article.BatchResult, error) {
+	results := make([]article.BatchResult, n)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for i := range results {
+		results[i].Err = inSavepoint(ctx, tx, func() error {
+			var err error
+			results[i].ID, err = apply(tx, i)
+			return err
+		})
+	}
+	if !article.BatchApplied(results) {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = article.ErrBatchAborted
+			}
+		}
+		return results, nil
+	}
+	return results, tx.Commit()
+}
+
+func (r ArticleRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// inSavepoint runs fn so that its failure does not undo the earlier work of
+// the transaction.
+func inSavepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT item`); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		_, _ = tx.ExecContext(ctx, `ROLLBACK TO item`)
+		_, _ = tx.ExecContext(ctx, `RELEASE item`)
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `RELEASE item`)
+	return err
+}
+
+func insert(ctx context.Context, q querier, a *entity.Article) error {
+	err := q.QueryRowContext(ctx, `INSERT INTO articles (title, slug, created_at, status) VALUES (?, ?, ?, ?) RETURNING id`,
+		a.Title, a.Slug, int64(a.CreatedAt), a.Status).Scan(&a.ID)
+	if err != nil {
+		return mapError(err)
+	}
+	if err := insertTags(ctx, q, a.ID, a.Tags); err != nil {
+		return err
+	}
+	events := []string{entity.EventArticleCreated}
+	if a.Status == entity.StatusPublished {
+		events = append(events, entity.EventArticlePublished)
+	}
+	return recordWrite(ctx, q, a.ID, nil, events...)
+}
+
+// remove deletes an article after recording its deletion.
+func remove(ctx context.Context, q querier, id int64) error {
+	before, err := load(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	if err := record(ctx, q, before, entity.EventArticleDeleted); err != nil {
+		return err
+	}
+	if err := affectedOne(q.ExecContext(ctx, `DELETE FROM articles WHERE id = ?`, id)); err != nil {
+		return err
+	}
+	return recordAudit(ctx, q, id, before, nil)
+}
+
+// load reads an article as currently stored in the transaction; it returns
+// article.ErrNotFound when there is no such article.
+func load(ctx context.Context, q querier, id int64) (*entity.Article, error) {
+	a := &entity.Article{}
+	if err := scanArticle(q.QueryRowContext(ctx, `SELECT `+columns+` FROM articles WHERE id = ?`, id), a); err != nil {
+		return nil, mapError(err)
+	}
+	return a, nil
+}
+
+// recordWrite records the events and the audit entry of a write that
+// changed an article from before, nil for a creation, to its current state.
+func recordWrite(ctx context.Context, q querier, id int64, before *entity.Article, events ...string) error {
+	after, err := load(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	if err := record(ctx, q, after, events...); err != nil {
+		return err
+	}
+	return recordAudit(ctx, q, id, before, after)
+}
+
+// record appends events about a to the outbox.
+func record(ctx context.Context, q querier, a *entity.Article, events ...string) error {
+	payload, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixNano()
+	for _, event := range events {
+		_, err := q.ExecContext(ctx, `INSERT INTO outbox (type, article_id, payload, occurred_at) VALUES (?, ?, ?, ?)`,
+			event, a.ID, payload, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertTags(ctx context.Context, q querier, id int64, tags []string) error {
+	for i, tag := range tags {
+		if _, err := q.ExecContext(ctx, `INSERT INTO article_tags (article_id, position, tag) VALUES (?, ?, ?)`, id, i, tag); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+func replaceTags(ctx context.Context, q querier, id int64, tags []string) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM article_tags WHERE article_id = ?`, id); err != nil {
+		return err
+	}
+	return insertTags(ctx, q, id, tags)
+}
+
+func query(ctx context.Context, q querier, sql string, args ...any) ([]*entity.Article, error) {
+	rows, err := q.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	articles := make([]*entity.Article, 0)
+	for rows.Next() {
+		a := &entity.Article{}
+		if err := scanArticle(rows, a); err != nil {
+			return nil, err
+		}
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanArticle(row scanner, a *entity.Article) error {
+	var (
+		createdAt int64
+		tags      string
+	)
+	if err := row.Scan(&a.ID, &a.Title, &a.Slug, &createdAt, &a.Status, &tags); err != nil {
+		return err
+	}
+	a.CreatedAt = uint64(createdAt)
+	return json.Unmarshal([]byte(tags), &a.Tags)
+}
+
+func offset(page uint16) int {
+	return (int(max(page, 1)) - 1) * pageSize
+}
+
+func jsonArray(values any) string {
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+// words splits s into lower case words of letters and digits, like the
+// unicode61 tokenizer of the FTS5 index.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// mapError translates SQLite errors to the sentinels of the article package;
+// other errors are returned unchanged.
+func mapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return article.ErrNotFound
+	}
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return fmt.Errorf("%w: %s", article.ErrAlreadyExist, sqliteErr.Error())
+	case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		return fmt.Errorf("%w: %s", article.ErrValidation, sqliteErr.Error())
+	default:
+		return err
+	}
+}
+
+// affectedOne maps a write that matched no row to article.ErrNotFound.
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return mapError(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return article.ErrNotFound
+	}
+	return nil
+}
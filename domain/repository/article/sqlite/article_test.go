@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/articletest"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
+	"m1-article-service/infrastructure/migrator"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestConformance runs against a fresh migrated database file per test.
+func TestConformance(t *testing.T) {
+	articletest.Run(t, func(t *testing.T) article.Article {
+		return NewArticleRepository(openDB(t))
+	})
+}
+
+func TestOutbox(t *testing.T) {
+	articletest.RunOutbox(t, func(t *testing.T) (article.Article, article.Outbox) {
+		db := openDB(t)
+		return NewArticleRepository(db), NewOutbox(db)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	articletest.RunAudit(t, func(t *testing.T) (article.Article, article.AuditLog) {
+		db := openDB(t)
+		return NewArticleRepository(db), NewAuditLog(db)
+	})
+}
+
+func openDB(t *testing.T) *sql.DB {
+	url := "sqlite://" + filepath.Join(t.TempDir(), "articles.db")
+	m, err := migrator.New(url, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+
+	db, err := database.OpenSQLite(context.Background(), &config.Config{DatabaseHost: url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"strings"
+	"time"
+)
+
+// AuditLog reads the audit_log table filled by ArticleRepository.
+type AuditLog struct {
+	db *sql.DB
+}
+
+func NewAuditLog(db *sql.DB) *AuditLog {
+	return &AuditLog{db: db}
+}
+
+func (l *AuditLog) Audit(ctx context.Context, filter article.AuditFilter, page uint16) ([]*entity.AuditEntry, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.ArticleID != 0 {
+		conditions, args = append(conditions, `article_id = ?`), append(args, filter.ArticleID)
+	}
+	if filter.Principal != "" {
+		conditions, args = append(conditions, `principal = ?`), append(args, filter.Principal)
+	}
+	if !filter.From.IsZero() {
+		conditions, args = append(conditions, `occurred_at >= ?`), append(args, filter.From.UnixNano())
+	}
+	if !filter.To.IsZero() {
+		conditions, args = append(conditions, `occurred_at <= ?`), append(args, filter.To.UnixNano())
+	}
+	query := `SELECT id, action, article_id, principal, request_id, peer, before, after, occurred_at FROM audit_log`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	rows, err := l.db.QueryContext(ctx, query+` ORDER BY id DESC LIMIT ? OFFSET ?`, append(args, pageSize, offset(page))...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]*entity.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			e             = &entity.AuditEntry{}
+			before, after sql.NullString
+			occurredAt    int64
+		)
+		err := rows.Scan(&e.ID, &e.Action, &e.ArticleID, &e.Actor.Principal, &e.Actor.RequestID, &e.Actor.Peer,
+			&before, &after, &occurredAt)
+		if err != nil {
+			return nil, err
+		}
+		if before.Valid {
+			if err := json.Unmarshal([]byte(before.String), &e.Before); err != nil {
+				return nil, err
+			}
+		}
+		if after.Valid {
+			if err := json.Unmarshal([]byte(after.String), &e.After); err != nil {
+				return nil, err
+			}
+		}
+		e.OccurredAt = time.Unix(0, occurredAt)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// recordAudit appends the entry of a write by the actor of ctx that changed
+// an article from before to after.
+func recordAudit(ctx context.Context, q querier, id int64, before, after *entity.Article) error {
+	snapshots := make([]sql.NullString, 2)
+	for i, a := range []*entity.Article{before, after} {
+		if a == nil {
+			continue
+		}
+		data, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		snapshots[i] = sql.NullString{String: string(data), Valid: true}
+	}
+	actor := article.ActorFromContext(ctx)
+	_, err := q.ExecContext(ctx, `INSERT INTO audit_log (action, article_id, principal, request_id, peer, before, after, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, article.AuditAction(before, after), id, actor.Principal, actor.RequestID, actor.Peer,
+		snapshots[0], snapshots[1], time.Now().UnixNano())
+	return err
+}
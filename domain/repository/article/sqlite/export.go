@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"strings"
+)
+
+// Export reads inside a read-only transaction; in WAL mode it keeps seeing
+// the snapshot of its first read while writers go on.
+func (r ArticleRepository) Export(ctx context.Context, filter article.ExportFilter, fn func(*entity.Article) error) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, args := exportQuery(filter)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a := &entity.Article{}
+		if err := scanArticle(rows, a); err != nil {
+			return err
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func exportQuery(filter article.ExportFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	where := func(condition string, arg any) {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+	if filter.CreatedFrom != 0 {
+		where("created_at >= ?", int64(filter.CreatedFrom))
+	}
+	if filter.CreatedTo != 0 {
+		where("created_at <= ?", int64(filter.CreatedTo))
+	}
+	if filter.Tag != "" {
+		where("EXISTS (SELECT 1 FROM article_tags WHERE article_id = articles.id AND tag = ?)", filter.Tag)
+	}
+	if filter.Status != "" {
+		where("status = ?", filter.Status)
+	}
+
+	query := `SELECT ` + columns + ` FROM articles`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	return query + ` ORDER BY id`, args
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+)
+
+// Import writes the articles row by row, each in its own savepoint, so that
+// the rows at fault are reported without undoing the others.
+func (r ArticleRepository) Import(ctx context.Context, articles []*entity.Article, opts article.ImportOptions) ([]article.ImportResult, error) {
+	results := make([]article.ImportResult, len(articles))
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	supersededBy := opts.SupersededBy(articles)
+	existing := map[string]int64{}
+	if opts.UpsertBySlug {
+		if existing, err = existingSlugs(ctx, tx, articles); err != nil {
+			return nil, err
+		}
+	}
+	for i, a := range articles {
+		if supersededBy[i] >= 0 {
+			continue
+		}
+		id, ok := existing[a.Slug]
+		results[i].Updated = ok
+		results[i].Err = inSavepoint(ctx, tx, func() error {
+			if !ok {
+				results[i].Published = a.Status == entity.StatusPublished
+				return insert(ctx, tx, a)
+			}
+			a.ID = id
+			before, err := load(ctx, tx, a.ID)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE articles SET title = ?, status = ? WHERE id = ?`, a.Title, a.Status, a.ID); err != nil {
+				return mapError(err)
+			}
+			if err := replaceTags(ctx, tx, a.ID, a.Tags); err != nil {
+				return err
+			}
+			events := []string{entity.EventArticleUpdated}
+			if before.Status != entity.StatusPublished && a.Status == entity.StatusPublished {
+				events = append(events, entity.EventArticlePublished)
+				results[i].Published = true
+			}
+			return recordWrite(ctx, tx, a.ID, before, events...)
+		})
+		if results[i].Err != nil {
+			results[i].Published = false
+		}
+	}
+
+	article.ResolveSuperseded(articles, results, supersededBy)
+	if opts.DryRun {
+		return results, nil
+	}
+	return results, tx.Commit()
+}
+
+// existingSlugs maps the slugs of articles that are already stored to the
+// ID of the oldest article using them.
+func existingSlugs(ctx context.Context, tx *sql.Tx, articles []*entity.Article) (map[string]int64, error) {
+	slugs := make([]string, len(articles))
+	for i, a := range articles {
+		slugs[i] = a.Slug
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT slug, min(id) FROM articles WHERE slug IN (SELECT value FROM json_each(?)) GROUP BY slug`, jsonArray(slugs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	existing := make(map[string]int64)
+	for rows.Next() {
+		var (
+			slug string
+			id   int64
+		)
+		if err := rows.Scan(&slug, &id); err != nil {
+			return nil, err
+		}
+		existing[slug] = id
+	}
+	return existing, rows.Err()
+}
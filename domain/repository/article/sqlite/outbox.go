@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"m1-article-service/domain/entity"
+	"sync"
+	"time"
+)
+
+// Outbox reads the outbox table filled by ArticleRepository.
+type Outbox struct {
+	db *sql.DB
+	// mu serialises Dispatch within the process; a database file should
+	// have a single relay.
+	mu sync.Mutex
+}
+
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+func (o *Outbox) Dispatch(ctx context.Context, limit int, fn func(*entity.Event) error) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events, err := o.pending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	delivered := make([]int64, 0, len(events))
+	var deliverErr error
+	for _, e := range events {
+		if deliverErr = fn(e); deliverErr != nil {
+			break
+		}
+		delivered = append(delivered, e.ID)
+	}
+	if len(delivered) > 0 {
+		if _, err := o.db.ExecContext(ctx, `DELETE FROM outbox WHERE id IN (SELECT value FROM json_each(?))`, jsonArray(delivered)); err != nil {
+			return 0, err
+		}
+	}
+	return len(delivered), deliverErr
+}
+
+func (o *Outbox) pending(ctx context.Context, limit int) ([]*entity.Event, error) {
+	rows, err := o.db.QueryContext(ctx, `SELECT id, type, article_id, payload, occurred_at FROM outbox ORDER BY id LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []*entity.Event
+	for rows.Next() {
+		var (
+			e          = &entity.Event{}
+			payload    string
+			occurredAt int64
+		)
+		if err := rows.Scan(&e.ID, &e.Type, &e.ArticleID, &payload, &occurredAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(payload), &e.Article); err != nil {
+			return nil, err
+		}
+		e.OccurredAt = time.Unix(0, occurredAt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,34 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Record is the request stored under an idempotency key. Hash identifies
+// the request; Response is nil while the request is in progress and
+// non-nil, possibly empty, once it completed.
+type Record struct {
+	Hash      string
+	Response  []byte
+	ExpiresAt time.Time
+}
+
+// Store keeps the responses of requests by idempotency key. A record that
+// expired at or before the current time counts as absent.
+type Store interface {
+	// Reserve claims key for the request identified by hash until
+	// expiresAt. When an unexpired record holds the key, it claims nothing
+	// and returns that record instead.
+	Reserve(ctx context.Context, key, hash string, now, expiresAt time.Time) (*Record, error)
+	// Complete stores the response of the request identified by hash and
+	// keeps it until expiresAt, provided that request still holds key in
+	// progress. It reports false when it did not, e.g. because the lease
+	// expired and another request took the key over.
+	Complete(ctx context.Context, key, hash string, response []byte, expiresAt time.Time) (bool, error)
+	// Release frees a key reserved by a request that failed, so that it
+	// can be retried. Completed keys are kept.
+	Release(ctx context.Context, key string) error
+	// Prune drops the records expired at now and returns how many.
+	Prune(ctx context.Context, now time.Time) (int64, error)
+}
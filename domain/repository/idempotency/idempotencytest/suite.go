@@ -0,0 +1,155 @@
+// Package idempotencytest holds the conformance tests shared by the
+// implementations of the idempotency store.
+package idempotencytest
+
+import (
+	"bytes"
+	"context"
+	"m1-article-service/domain/repository/idempotency"
+	"testing"
+	"time"
+)
+
+// now is a fixed instant, truncated to microseconds so that every backend
+// stores it unchanged.
+var now = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// Run checks an implementation; newStore must return an empty store.
+func Run(t *testing.T, newStore func(t *testing.T) idempotency.Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, store idempotency.Store)
+	}{
+		{"ReserveOnce", testReserveOnce},
+		{"Complete", testComplete},
+		{"CompleteHeldKeyOnly", testCompleteHeld},
+		{"Release", testRelease},
+		{"ExpiredKeyReserved", testExpired},
+		{"Prune", testPrune},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, newStore(t))
+		})
+	}
+}
+
+func complete(t *testing.T, store idempotency.Store, key, hash string, response []byte, expiresAt time.Time) {
+	t.Helper()
+	ok, err := store.Complete(context.Background(), key, hash, response, expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatalf("complete of %s by %s did not take", key, hash)
+	}
+}
+
+func reserve(t *testing.T, store idempotency.Store, key, hash string, at time.Time) *idempotency.Record {
+	t.Helper()
+	r, err := store.Reserve(context.Background(), key, hash, at, at.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func testReserveOnce(t *testing.T, store idempotency.Store) {
+	if r := reserve(t, store, "a", "h1", now); r != nil {
+		t.Fatalf("first reserve returned %+v, want nil", r)
+	}
+	r := reserve(t, store, "a", "h2", now)
+	if r == nil || r.Hash != "h1" || r.Response != nil || !r.ExpiresAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("second reserve returned %+v, want the in-progress record of h1", r)
+	}
+	if r := reserve(t, store, "b", "h1", now); r != nil {
+		t.Fatalf("reserve of another key returned %+v, want nil", r)
+	}
+}
+
+func testComplete(t *testing.T, store idempotency.Store) {
+	ctx := context.Background()
+	reserve(t, store, "a", "h", now)
+	reserve(t, store, "b", "h", now)
+	complete(t, store, "a", "h", []byte("response"), now.Add(time.Hour))
+	complete(t, store, "b", "h", nil, now.Add(time.Hour))
+	r := reserve(t, store, "a", "h", now.Add(30*time.Minute))
+	if r == nil || !bytes.Equal(r.Response, []byte("response")) || !r.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("reserve of a completed key returned %+v", r)
+	}
+	if r := reserve(t, store, "b", "h", now); r == nil || r.Response == nil || len(r.Response) != 0 {
+		t.Fatalf("reserve of a key completed without response returned %+v, want an empty response", r)
+	}
+	if err := store.Release(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if r := reserve(t, store, "a", "h", now); r == nil {
+		t.Fatal("release dropped a completed key")
+	}
+}
+
+func testCompleteHeld(t *testing.T, store idempotency.Store) {
+	ctx := context.Background()
+	reserve(t, store, "a", "h1", now)
+	// the lease of h1 expired and h2 took the key over
+	reserve(t, store, "a", "h2", now.Add(time.Minute))
+	for _, c := range []struct{ key, hash string }{{"a", "h1"}, {"unknown", "h1"}} {
+		ok, err := store.Complete(ctx, c.key, c.hash, []byte("lost"), now.Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Errorf("complete of %s by %s took, want it refused", c.key, c.hash)
+		}
+	}
+	complete(t, store, "a", "h2", []byte("kept"), now.Add(time.Hour))
+	if ok, err := store.Complete(ctx, "a", "h2", []byte("again"), now.Add(time.Hour)); err != nil || ok {
+		t.Errorf("second complete = %v, %v, want it refused", ok, err)
+	}
+	if r := reserve(t, store, "a", "h2", now.Add(time.Minute)); r == nil || string(r.Response) != "kept" {
+		t.Errorf("reserve after complete returned %+v, want the response of h2", r)
+	}
+}
+
+func testRelease(t *testing.T, store idempotency.Store) {
+	reserve(t, store, "a", "h1", now)
+	if err := store.Release(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if r := reserve(t, store, "a", "h2", now); r != nil {
+		t.Fatalf("reserve after release returned %+v, want nil", r)
+	}
+	if err := store.Release(context.Background(), "unknown"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testExpired(t *testing.T, store idempotency.Store) {
+	reserve(t, store, "a", "h1", now)
+	complete(t, store, "a", "h1", []byte("old"), now.Add(time.Hour))
+	later := now.Add(time.Hour)
+	if r := reserve(t, store, "a", "h2", later); r != nil {
+		t.Fatalf("reserve of an expired key returned %+v, want nil", r)
+	}
+	r := reserve(t, store, "a", "h3", later)
+	if r == nil || r.Hash != "h2" || r.Response != nil {
+		t.Fatalf("expired record was not replaced, got %+v", r)
+	}
+}
+
+func testPrune(t *testing.T, store idempotency.Store) {
+	ctx := context.Background()
+	reserve(t, store, "a", "h", now)
+	reserve(t, store, "b", "h", now)
+	complete(t, store, "b", "h", []byte("kept"), now.Add(time.Hour))
+	pruned, err := store.Prune(ctx, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned %d records, want 1", pruned)
+	}
+	if r := reserve(t, store, "b", "h", now.Add(time.Minute)); r == nil || string(r.Response) != "kept" {
+		t.Errorf("prune dropped an unexpired record, got %+v", r)
+	}
+}
@@ -0,0 +1,69 @@
+// Package memory provides an in-process implementation of the idempotency
+// store for tests and local demos.
+package memory
+
+import (
+	"context"
+	"m1-article-service/domain/repository/idempotency"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store keeps the records in a map by key, copied on the way in and out.
+type Store struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func NewStore() *Store {
+	return &Store{records: make(map[string]idempotency.Record)}
+}
+
+func (s *Store) Reserve(_ context.Context, key, hash string, now, expiresAt time.Time) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.records[key]; ok && r.ExpiresAt.After(now) {
+		r.Response = slices.Clone(r.Response)
+		return &r, nil
+	}
+	s.records[key] = idempotency.Record{Hash: hash, ExpiresAt: expiresAt}
+	return nil, nil
+}
+
+func (s *Store) Complete(_ context.Context, key, hash string, response []byte, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok || r.Hash != hash || r.Response != nil {
+		return false, nil
+	}
+	r.Response, r.ExpiresAt = slices.Clone(response), expiresAt
+	if r.Response == nil {
+		r.Response = []byte{}
+	}
+	s.records[key] = r
+	return true, nil
+}
+
+func (s *Store) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.records[key]; ok && r.Response == nil {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *Store) Prune(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pruned int64
+	for key, r := range s.records {
+		if !r.ExpiresAt.After(now) {
+			delete(s.records, key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
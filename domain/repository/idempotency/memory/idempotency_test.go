@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"m1-article-service/domain/repository/idempotency"
+	"m1-article-service/domain/repository/idempotency/idempotencytest"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	idempotencytest.Run(t, func(t *testing.T) idempotency.Store {
+		return NewStore()
+	})
+}
@@ -0,0 +1,70 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/repository/idempotency"
+	"time"
+)
+
+type Store struct {
+	conn *pgxpool.Pool
+}
+
+func NewStore(conn *pgxpool.Pool) *Store {
+	return &Store{conn: conn}
+}
+
+// Reserve inserts the key or takes over its expired record in one
+// statement, so that concurrent requests never both claim a key.
+func (s *Store) Reserve(ctx context.Context, key, hash string, now, expiresAt time.Time) (*idempotency.Record, error) {
+	for {
+		tag, err := s.conn.Exec(ctx, `INSERT INTO idempotency_keys (key,request_hash,expires_at) VALUES($1,$2,$4)
+			ON CONFLICT (key) DO UPDATE SET request_hash=EXCLUDED.request_hash, response=NULL, expires_at=EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= $3`, key, hash, now, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if tag.RowsAffected() == 1 {
+			return nil, nil
+		}
+		r := &idempotency.Record{}
+		err = s.conn.QueryRow(ctx, `SELECT request_hash,response,expires_at FROM idempotency_keys WHERE key=$1`, key).
+			Scan(&r.Hash, &r.Response, &r.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// pruned in the meantime, try again
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+}
+
+func (s *Store) Complete(ctx context.Context, key, hash string, response []byte, expiresAt time.Time) (bool, error) {
+	if response == nil {
+		response = []byte{}
+	}
+	tag, err := s.conn.Exec(ctx, `UPDATE idempotency_keys SET response=$3, expires_at=$4
+		WHERE key=$1 AND request_hash=$2 AND response IS NULL`, key, hash, response, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	_, err := s.conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE key=$1 AND response IS NULL`, key)
+	return err
+}
+
+func (s *Store) Prune(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := s.conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"m1-article-service/domain/repository/idempotency"
+	"time"
+)
+
+// Store keeps the records in the idempotency_keys table, with expires_at in
+// Unix nanoseconds.
+type Store struct {
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Reserve inserts the key or takes over its expired record in one
+// statement, so that concurrent requests never both claim a key.
+func (s *Store) Reserve(ctx context.Context, key, hash string, now, expiresAt time.Time) (*idempotency.Record, error) {
+	for {
+		res, err := s.db.ExecContext(ctx, `INSERT INTO idempotency_keys (key, request_hash, expires_at) VALUES (?, ?, ?)
+			ON CONFLICT (key) DO UPDATE SET request_hash = excluded.request_hash, response = NULL, expires_at = excluded.expires_at
+			WHERE idempotency_keys.expires_at <= ?`, key, hash, expiresAt.UnixNano(), now.UnixNano())
+		if err != nil {
+			return nil, err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if n == 1 {
+			return nil, nil
+		}
+		var (
+			r         = &idempotency.Record{}
+			completed bool
+			expiresNs int64
+		)
+		err = s.db.QueryRowContext(ctx, `SELECT request_hash, response, response IS NOT NULL, expires_at FROM idempotency_keys WHERE key = ?`, key).
+			Scan(&r.Hash, &r.Response, &completed, &expiresNs)
+		if errors.Is(err, sql.ErrNoRows) {
+			// pruned in the meantime, try again
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if completed && r.Response == nil {
+			// the driver scans empty blobs as nil
+			r.Response = []byte{}
+		}
+		r.ExpiresAt = time.Unix(0, expiresNs)
+		return r, nil
+	}
+}
+
+func (s *Store) Complete(ctx context.Context, key, hash string, response []byte, expiresAt time.Time) (bool, error) {
+	if response == nil {
+		response = []byte{}
+	}
+	res, err := s.db.ExecContext(ctx, `UPDATE idempotency_keys SET response = ?, expires_at = ?
+		WHERE key = ? AND request_hash = ? AND response IS NULL`,
+		response, expiresAt.UnixNano(), key, hash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = ? AND response IS NULL`, key)
+	return err
+}
+
+func (s *Store) Prune(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= ?`, now.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
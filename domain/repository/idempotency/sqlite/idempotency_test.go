@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"m1-article-service/domain/repository/idempotency"
+	"m1-article-service/domain/repository/idempotency/idempotencytest"
+	"m1-article-service/infrastructure/config"
+	"m1-article-service/infrastructure/database"
+	"m1-article-service/infrastructure/migrator"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestConformance runs against a fresh migrated database file per test.
+func TestConformance(t *testing.T) {
+	idempotencytest.Run(t, func(t *testing.T) idempotency.Store {
+		url := "sqlite://" + filepath.Join(t.TempDir(), "articles.db")
+		m, err := migrator.New(url, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Up(); err != nil {
+			t.Fatal(err)
+		}
+		m.Close()
+
+		db, err := database.OpenSQLite(context.Background(), &config.Config{DatabaseHost: url})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return NewStore(db)
+	})
+}
@@ -0,0 +1,209 @@
+// Package memory provides an in-process implementation of the webhook
+// repository for tests and local demos.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	"slices"
+	"sync"
+	"time"
+)
+
+const pageSize = 10
+
+// WebhookRepository keeps webhooks and deliveries in slices ordered by ID,
+// copied on the way in and out.
+type WebhookRepository struct {
+	mu             sync.Mutex
+	webhooks       []*entity.Webhook
+	deliveries     []*entity.WebhookDelivery
+	attempts       []entity.WebhookAttempt
+	lastWebhookID  int64
+	lastDeliveryID int64
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{}
+}
+
+func (r *WebhookRepository) Create(_ context.Context, w *entity.Webhook) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastWebhookID++
+	w.ID, w.CreatedAt = r.lastWebhookID, time.Now()
+	r.webhooks = append(r.webhooks, cloneWebhook(w))
+	return w.ID, nil
+}
+
+func (r *WebhookRepository) Get(_ context.Context, id int64) (*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.webhookIndex(id)
+	if i < 0 {
+		return nil, webhook.ErrNotFound
+	}
+	return cloneWebhook(r.webhooks[i]), nil
+}
+
+func (r *WebhookRepository) List(_ context.Context) ([]*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	webhooks := make([]*entity.Webhook, 0, len(r.webhooks))
+	for i := len(r.webhooks) - 1; i >= 0; i-- {
+		webhooks = append(webhooks, cloneWebhook(r.webhooks[i]))
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.webhookIndex(id)
+	if i < 0 {
+		return webhook.ErrNotFound
+	}
+	r.webhooks = slices.Delete(r.webhooks, i, i+1)
+	r.deliveries = slices.DeleteFunc(r.deliveries, func(d *entity.WebhookDelivery) bool { return d.WebhookID == id })
+	r.attempts = slices.DeleteFunc(r.attempts, func(a entity.WebhookAttempt) bool { return r.deliveryIndex(a.DeliveryID) < 0 })
+	return nil
+}
+
+func (r *WebhookRepository) Enqueue(_ context.Context, e *entity.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, w := range r.webhooks {
+		if !w.Matches(e.Type) || slices.ContainsFunc(r.deliveries, func(d *entity.WebhookDelivery) bool {
+			return d.WebhookID == w.ID && d.EventID == e.ID
+		}) {
+			continue
+		}
+		r.lastDeliveryID++
+		r.deliveries = append(r.deliveries, &entity.WebhookDelivery{
+			ID:            r.lastDeliveryID,
+			WebhookID:     w.ID,
+			EventID:       e.ID,
+			EventType:     e.Type,
+			Payload:       payload,
+			Status:        entity.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Claim(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == entity.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b *entity.WebhookDelivery) int { return a.NextAttemptAt.Compare(b.NextAttemptAt) })
+	claimed := make([]*entity.WebhookDelivery, 0, min(limit, len(due)))
+	for _, d := range due[:min(limit, len(due))] {
+		d.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, cloneDelivery(d))
+	}
+	return claimed, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(_ context.Context, d *entity.WebhookDelivery, a entity.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.deliveryIndex(d.ID)
+	if i < 0 {
+		return webhook.ErrNotFound
+	}
+	stored := r.deliveries[i]
+	stored.Status, stored.Attempts, stored.NextAttemptAt = d.Status, d.Attempts, d.NextAttemptAt
+	stored.LastError, stored.DeliveredAt = d.LastError, d.DeliveredAt
+	a.DeliveryID = d.ID
+	r.attempts = append(r.attempts, a)
+	return nil
+}
+
+func (r *WebhookRepository) Deliveries(_ context.Context, filter webhook.DeliveryFilter, page uint16) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matched []*entity.WebhookDelivery
+	for i := len(r.deliveries) - 1; i >= 0; i-- {
+		d := r.deliveries[i]
+		if (filter.WebhookID == 0 || d.WebhookID == filter.WebhookID) && (filter.Status == "" || d.Status == filter.Status) {
+			matched = append(matched, d)
+		}
+	}
+	start := min((int(max(page, 1))-1)*pageSize, len(matched))
+	end := min(start+pageSize, len(matched))
+	deliveries := make([]*entity.WebhookDelivery, 0, end-start)
+	for _, d := range matched[start:end] {
+		deliveries = append(deliveries, cloneDelivery(d))
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) Delivery(_ context.Context, id int64) (*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.deliveryIndex(id)
+	if i < 0 {
+		return nil, webhook.ErrNotFound
+	}
+	return cloneDelivery(r.deliveries[i]), nil
+}
+
+func (r *WebhookRepository) Attempts(_ context.Context, deliveryID int64) ([]entity.WebhookAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attempts []entity.WebhookAttempt
+	for _, a := range r.attempts {
+		if a.DeliveryID == deliveryID {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts, nil
+}
+
+func (r *WebhookRepository) Replay(_ context.Context, webhookID int64, ids []int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var replayed int64
+	for _, d := range r.deliveries {
+		if d.WebhookID != webhookID || d.Status != entity.DeliveryDead || (len(ids) > 0 && !slices.Contains(ids, d.ID)) {
+			continue
+		}
+		d.Status, d.Attempts, d.NextAttemptAt, d.LastError = entity.DeliveryPending, 0, time.Now(), ""
+		replayed++
+	}
+	return replayed, nil
+}
+
+func (r *WebhookRepository) webhookIndex(id int64) int {
+	return slices.IndexFunc(r.webhooks, func(w *entity.Webhook) bool { return w.ID == id })
+}
+
+func (r *WebhookRepository) deliveryIndex(id int64) int {
+	return slices.IndexFunc(r.deliveries, func(d *entity.WebhookDelivery) bool { return d.ID == id })
+}
+
+func cloneWebhook(w *entity.Webhook) *entity.Webhook {
+	c := *w
+	c.Events = slices.Clone(w.Events)
+	return &c
+}
+
+func cloneDelivery(d *entity.WebhookDelivery) *entity.WebhookDelivery {
+	c := *d
+	c.Payload = slices.Clone(d.Payload)
+	return &c
+}
@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"m1-article-service/domain/repository/webhook"
+	"m1-article-service/domain/repository/webhook/webhooktest"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	webhooktest.Run(t, func(t *testing.T) webhook.Webhook {
+		return NewWebhookRepository()
+	})
+}
@@ -0,0 +1,185 @@
+package pgx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	"time"
+)
+
+const pageSize = 10
+
+const (
+	webhookColumns  = `id,url,secret,events,created_at`
+	deliveryColumns = `id,webhook_id,event_id,event_type,payload,status,attempts,next_attempt_at,last_error,created_at,delivered_at`
+)
+
+type WebhookRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewWebhookRepository(conn *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{conn: conn}
+}
+
+func (r WebhookRepository) Create(ctx context.Context, w *entity.Webhook) (int64, error) {
+	// A nil slice is encoded as NULL, which the events column rejects.
+	events := w.Events
+	if events == nil {
+		events = []string{}
+	}
+	err := r.conn.QueryRow(ctx, `INSERT INTO webhooks (url,secret,events) VALUES($1,$2,$3) RETURNING id,created_at`,
+		w.URL, w.Secret, events).Scan(&w.ID, &w.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return w.ID, nil
+}
+
+func (r WebhookRepository) Get(ctx context.Context, id int64) (*entity.Webhook, error) {
+	rows, err := r.conn.Query(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id=$1`, id)
+	if err != nil {
+		return nil, err
+	}
+	w, err := pgx.CollectExactlyOneRow(rows, scanWebhook)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, webhook.ErrNotFound
+	}
+	return w, err
+}
+
+func (r WebhookRepository) List(ctx context.Context) ([]*entity.Webhook, error) {
+	rows, err := r.conn.Query(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanWebhook)
+}
+
+func (r WebhookRepository) Delete(ctx context.Context, id int64) error {
+	return affectedOne(r.conn.Exec(ctx, `DELETE FROM webhooks WHERE id=$1`, id))
+}
+
+func (r WebhookRepository) Enqueue(ctx context.Context, e *entity.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx, `INSERT INTO webhook_deliveries (webhook_id,event_id,event_type,payload)
+		SELECT id,$1::bigint,$2::text,$3::jsonb FROM webhooks WHERE cardinality(events) = 0 OR $2 = ANY(events)
+		ON CONFLICT (webhook_id,event_id) DO NOTHING`, e.ID, e.Type, payload)
+	return err
+}
+
+// Claim skips the rows locked by concurrent claims, so that several workers
+// never claim the same delivery.
+func (r WebhookRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.conn.Query(ctx, `UPDATE webhook_deliveries SET next_attempt_at=$2 WHERE id IN (
+			SELECT id FROM webhook_deliveries WHERE status='pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED
+		) RETURNING `+deliveryColumns, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanDelivery)
+}
+
+func (r WebhookRepository) RecordAttempt(ctx context.Context, d *entity.WebhookDelivery, a entity.WebhookAttempt) error {
+	return pgx.BeginFunc(ctx, r.conn, func(tx pgx.Tx) error {
+		var deliveredAt *time.Time
+		if !d.DeliveredAt.IsZero() {
+			deliveredAt = &d.DeliveredAt
+		}
+		err := affectedOne(tx.Exec(ctx, `UPDATE webhook_deliveries SET status=$1,attempts=$2,next_attempt_at=$3,last_error=$4,delivered_at=$5 WHERE id=$6`,
+			d.Status, d.Attempts, d.NextAttemptAt, d.LastError, deliveredAt, d.ID))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO webhook_attempts (delivery_id,attempted_at,status_code,error,duration_ms) VALUES($1,$2,$3,$4,$5)`,
+			d.ID, a.AttemptedAt, a.StatusCode, a.Error, a.Duration.Milliseconds())
+		return err
+	})
+}
+
+func (r WebhookRepository) Deliveries(ctx context.Context, filter webhook.DeliveryFilter, page uint16) ([]*entity.WebhookDelivery, error) {
+	offset := (int(max(page, 1)) - 1) * pageSize
+	rows, err := r.conn.Query(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE ($1 = 0 OR webhook_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY id DESC LIMIT $3 OFFSET $4`, filter.WebhookID, filter.Status, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanDelivery)
+}
+
+func (r WebhookRepository) Delivery(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	rows, err := r.conn.Query(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id=$1`, id)
+	if err != nil {
+		return nil, err
+	}
+	d, err := pgx.CollectExactlyOneRow(rows, scanDelivery)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, webhook.ErrNotFound
+	}
+	return d, err
+}
+
+func (r WebhookRepository) Attempts(ctx context.Context, deliveryID int64) ([]entity.WebhookAttempt, error) {
+	rows, err := r.conn.Query(ctx, `SELECT delivery_id,attempted_at,status_code,error,duration_ms FROM webhook_attempts
+		WHERE delivery_id=$1 ORDER BY id`, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.WebhookAttempt, error) {
+		var (
+			a        entity.WebhookAttempt
+			duration int64
+		)
+		err := row.Scan(&a.DeliveryID, &a.AttemptedAt, &a.StatusCode, &a.Error, &duration)
+		a.Duration = time.Duration(duration) * time.Millisecond
+		return a, err
+	})
+}
+
+func (r WebhookRepository) Replay(ctx context.Context, webhookID int64, ids []int64) (int64, error) {
+	tag, err := r.conn.Exec(ctx, `UPDATE webhook_deliveries SET status='pending',attempts=0,next_attempt_at=now(),last_error=''
+		WHERE webhook_id=$1 AND status='dead' AND (cardinality($2::bigint[]) = 0 OR id = ANY($2))`, webhookID, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanWebhook(row pgx.CollectableRow) (*entity.Webhook, error) {
+	w := &entity.Webhook{}
+	return w, row.Scan(&w.ID, &w.URL, &w.Secret, &w.Events, &w.CreatedAt)
+}
+
+func scanDelivery(row pgx.CollectableRow) (*entity.WebhookDelivery, error) {
+	var (
+		d           = &entity.WebhookDelivery{}
+		deliveredAt *time.Time
+	)
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastError, &d.CreatedAt, &deliveredAt)
+	if deliveredAt != nil {
+		d.DeliveredAt = *deliveredAt
+	}
+	return d, err
+}
+
+// affectedOne maps a write that matched no row to webhook.ErrNotFound.
+func affectedOne(tag pgconn.CommandTag, err error) error {
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return webhook.ErrNotFound
+	}
+	return nil
+}
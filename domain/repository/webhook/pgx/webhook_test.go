@@ -0,0 +1,41 @@
+package pgx
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/domain/repository/webhook"
+	"m1-article-service/domain/repository/webhook/webhooktest"
+	"m1-article-service/infrastructure/migrator"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestConformance runs against the database in TEST_DATABASE_URL, whose
+// webhook tables are truncated before every test.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	m, err := migrator.New(dsn, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	webhooktest.Run(t, func(t *testing.T) webhook.Webhook {
+		if _, err := pool.Exec(context.Background(), `TRUNCATE webhooks, webhook_deliveries, webhook_attempts RESTART IDENTITY`); err != nil {
+			t.Fatal(err)
+		}
+		return NewWebhookRepository(pool)
+	})
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation error")
+)
+
+// DeliveryFilter selects deliveries; zero fields match everything.
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    string
+}
+
+// Webhook stores webhook subscriptions and the state and log of their
+// deliveries. Lists are returned in pages of ten, newest first.
+type Webhook interface {
+	Create(context.Context, *entity.Webhook) (int64, error)
+	Get(context.Context, int64) (*entity.Webhook, error)
+	List(context.Context) ([]*entity.Webhook, error)
+	// Delete removes a webhook with its deliveries.
+	Delete(context.Context, int64) error
+
+	// Enqueue adds a pending delivery of the event for every webhook
+	// subscribed to its type. Enqueueing an event again adds nothing.
+	Enqueue(context.Context, *entity.Event) error
+	// Claim returns up to limit pending deliveries due at now and
+	// postpones them by lease, so that other workers skip them until the
+	// claimer records an attempt or fails to within the lease.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error)
+	// RecordAttempt logs an attempt and stores the resulting status,
+	// attempt count, next attempt and error of its delivery.
+	RecordAttempt(context.Context, *entity.WebhookDelivery, entity.WebhookAttempt) error
+
+	Deliveries(context.Context, DeliveryFilter, uint16) ([]*entity.WebhookDelivery, error)
+	Delivery(context.Context, int64) (*entity.WebhookDelivery, error)
+	// Attempts returns the log of a delivery, oldest first.
+	Attempts(context.Context, int64) ([]entity.WebhookAttempt, error)
+	// Replay makes the dead deliveries of a webhook pending again with no
+	// attempts: those listed, or all of them when ids is empty. It returns
+	// how many were replayed.
+	Replay(ctx context.Context, webhookID int64, ids []int64) (int64, error)
+}
@@ -0,0 +1,151 @@
+// Package webhooktest holds the conformance tests shared by the
+// implementations of the webhook repository.
+package webhooktest
+
+import (
+	"context"
+	"errors"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	"testing"
+	"time"
+)
+
+// Run checks an implementation; newRepo must return an empty repository.
+func Run(t *testing.T, newRepo func(t *testing.T) webhook.Webhook) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo webhook.Webhook)
+	}{
+		{"CreateGetList", testCreateGetList},
+		{"EnqueueClaim", testEnqueueClaim},
+		{"RecordAttemptReplay", testRecordAttemptReplay},
+		{"Delete", testDelete},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, newRepo(t))
+		})
+	}
+}
+
+func create(t *testing.T, repo webhook.Webhook, events ...string) *entity.Webhook {
+	t.Helper()
+	w := &entity.Webhook{URL: "https://example.com/hook", Secret: "secret", Events: events}
+	if _, err := repo.Create(context.Background(), w); err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func enqueue(t *testing.T, repo webhook.Webhook, id int64, eventType string) {
+	t.Helper()
+	e := &entity.Event{ID: id, Type: eventType, ArticleID: 1, OccurredAt: time.Now().UTC()}
+	if err := repo.Enqueue(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// claim claims the deliveries due a second from now, so that those just
+// enqueued are due whichever clock the repository uses.
+func claim(t *testing.T, repo webhook.Webhook) []*entity.WebhookDelivery {
+	t.Helper()
+	claimed, err := repo.Claim(context.Background(), time.Now().Add(time.Second), time.Minute, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return claimed
+}
+
+func testCreateGetList(t *testing.T, repo webhook.Webhook) {
+	ctx := context.Background()
+	all := create(t, repo)
+	created := create(t, repo, entity.EventArticleCreated)
+	if all.ID == 0 || created.ID == 0 || all.CreatedAt.IsZero() {
+		t.Fatalf("created webhooks = %+v, %+v", all, created)
+	}
+
+	got, err := repo.Get(ctx, all.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != all.URL || got.Secret != all.Secret || len(got.Events) != 0 {
+		t.Errorf("webhook without events = %+v", got)
+	}
+	if _, err := repo.Get(ctx, created.ID+1); !errors.Is(err, webhook.ErrNotFound) {
+		t.Errorf("Get of an unknown webhook: %v, want ErrNotFound", err)
+	}
+
+	webhooks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 2 || webhooks[0].ID != created.ID || len(webhooks[0].Events) != 1 {
+		t.Errorf("webhooks = %+v, want the one with events first", webhooks)
+	}
+}
+
+func testEnqueueClaim(t *testing.T, repo webhook.Webhook) {
+	all := create(t, repo)
+	create(t, repo, entity.EventArticleCreated)
+	enqueue(t, repo, 1, entity.EventArticleUpdated)
+	enqueue(t, repo, 1, entity.EventArticleUpdated)
+
+	claimed := claim(t, repo)
+	if len(claimed) != 1 || claimed[0].WebhookID != all.ID || claimed[0].EventID != 1 || claimed[0].Status != entity.DeliveryPending {
+		t.Fatalf("claimed = %+v, want one pending delivery to the webhook of every event", claimed)
+	}
+	if claimed := claim(t, repo); len(claimed) != 0 {
+		t.Errorf("claimed %d leased deliveries again", len(claimed))
+	}
+}
+
+func testRecordAttemptReplay(t *testing.T, repo webhook.Webhook) {
+	ctx := context.Background()
+	w := create(t, repo)
+	enqueue(t, repo, 1, entity.EventArticleCreated)
+	d := claim(t, repo)[0]
+
+	d.Status, d.Attempts, d.LastError = entity.DeliveryDead, 1, "connection refused"
+	attempt := entity.WebhookAttempt{AttemptedAt: time.Now().UTC(), Error: d.LastError, Duration: time.Second}
+	if err := repo.RecordAttempt(ctx, d, attempt); err != nil {
+		t.Fatal(err)
+	}
+	attempts, err := repo.Attempts(ctx, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 1 || attempts[0].DeliveryID != d.ID || attempts[0].Error != d.LastError || attempts[0].Duration != time.Second {
+		t.Errorf("attempts = %+v", attempts)
+	}
+	dead, err := repo.Deliveries(ctx, webhook.DeliveryFilter{WebhookID: w.ID, Status: entity.DeliveryDead}, 1)
+	if err != nil || len(dead) != 1 {
+		t.Fatalf("dead deliveries = %+v, %v", dead, err)
+	}
+
+	if n, err := repo.Replay(ctx, w.ID, nil); err != nil || n != 1 {
+		t.Fatalf("Replay = %d, %v, want 1", n, err)
+	}
+	got, err := repo.Delivery(ctx, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != entity.DeliveryPending || got.Attempts != 0 || got.LastError != "" {
+		t.Errorf("replayed delivery = %+v", got)
+	}
+}
+
+func testDelete(t *testing.T, repo webhook.Webhook) {
+	ctx := context.Background()
+	w := create(t, repo)
+	enqueue(t, repo, 1, entity.EventArticleCreated)
+	if err := repo.Delete(ctx, w.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, w.ID); !errors.Is(err, webhook.ErrNotFound) {
+		t.Errorf("second Delete: %v, want ErrNotFound", err)
+	}
+	if deliveries, err := repo.Deliveries(ctx, webhook.DeliveryFilter{}, 1); err != nil || len(deliveries) != 0 {
+		t.Errorf("deliveries after Delete = %+v, %v", deliveries, err)
+	}
+}
@@ -2,11 +2,19 @@ package article
 
 import (
 	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"m1-article-service/domain/entity"
 	"m1-article-service/domain/repository/article"
 	loggerInfra "m1-article-service/infrastructure/log"
+	"strings"
 )
 
+var tracer = otel.Tracer("m1-article-service/domain/service/article")
+
 type Service struct {
 	articleRepository article.Article
 	logger            loggerInfra.Logger
@@ -19,45 +27,86 @@ func NewService(logger loggerInfra.Logger, articleRepo article.Article) *Service
 	}
 }
 
+// Create stores a valid article; an invalid one is rejected with an error
+// wrapping article.ErrValidation, see Validate.
 func (s Service) Create(ctx context.Context, article *entity.Article) (int64, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.Create")
+	defer span.End()
+	if err := Validate(article); err != nil {
+		return 0, err
+	}
 	id, err := s.articleRepository.Create(ctx, article)
 	if err != nil {
-		s.logger.Error(err)
+		s.fail(ctx, span, err)
 		return 0, err
 	}
+	span.SetAttributes(attribute.Int64("article.id", id))
 	return id, err
 }
 
 func (s Service) Update(ctx context.Context, article *entity.Article) error {
+	ctx, span := tracer.Start(ctx, "article.Service.Update", trace.WithAttributes(attribute.Int64("article.id", article.ID)))
+	defer span.End()
+	if err := Validate(article); err != nil {
+		return err
+	}
 	if err := s.articleRepository.Update(ctx, article); err != nil {
-		s.logger.Error(err)
+		s.fail(ctx, span, err)
 		return err
 	}
 	return nil
 }
 
 func (s Service) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "article.Service.Delete", trace.WithAttributes(attribute.Int64("article.id", id)))
+	defer span.End()
 	if err := s.articleRepository.Delete(ctx, id); err != nil {
-		s.logger.Error(err)
+		s.fail(ctx, span, err)
 		return err
 	}
 	return nil
 }
 
 func (s Service) Detail(ctx context.Context, id int64) (*entity.Article, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.Detail", trace.WithAttributes(attribute.Int64("article.id", id)))
+	defer span.End()
 	article, err := s.articleRepository.Detail(ctx, id)
 	if err != nil {
-		s.logger.Error(err)
+		s.fail(ctx, span, err)
 		return nil, err
 	}
 	return article, nil
 }
 
 func (s Service) List(ctx context.Context, page uint16) ([]*entity.Article, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.List", trace.WithAttributes(attribute.Int("page", int(page))))
+	defer span.End()
 	articles, err := s.articleRepository.List(ctx, page)
 	if err != nil {
-		s.logger.Error(err)
+		s.fail(ctx, span, err)
 		return nil, err
 	}
 	return articles, err
 }
+
+// Search finds articles by the words of their title, slug and tags.
+func (s Service) Search(ctx context.Context, query string, page uint16) ([]*entity.Article, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.Search", trace.WithAttributes(attribute.Int("page", int(page))))
+	defer span.End()
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: query is required", article.ErrValidation)
+	}
+	articles, err := s.articleRepository.Search(ctx, query, page)
+	if err != nil {
+		s.fail(ctx, span, err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// fail logs err and records it on the span of the current operation.
+func (s Service) fail(ctx context.Context, span trace.Span, err error) {
+	s.logger.Error(ctx, err)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
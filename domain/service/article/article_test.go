@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
 	infraMock "m1-article-service/mock/infrastructure"
 	mock_article "m1-article-service/mock/repository"
+	"strings"
 	"testing"
 	"time"
 )
@@ -46,7 +48,7 @@ func TestService_Create(t *testing.T) {
 			name: "RepoError",
 			loggerMock: func() *infraMock.MockLog {
 				loggerInfra := infraMock.NewMockLog(ctrl)
-				loggerInfra.EXPECT().Error(err).Return()
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
 				return loggerInfra
 			},
 			articleRepoMock: func() *mock_article.MockArticle {
@@ -58,6 +60,18 @@ func TestService_Create(t *testing.T) {
 			error:   err,
 			ctx:     context.Background(),
 		},
+		{
+			name: "InvalidArticle",
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			article: entity.NewArticle(strings.Repeat("t", 51), "slug", nil),
+			error:   article.ErrValidation,
+			ctx:     context.Background(),
+		},
 	}
 
 	for _, test := range tests {
@@ -127,7 +141,7 @@ func TestService_Update(t *testing.T) {
 			name: "RepoError",
 			loggerMock: func() *infraMock.MockLog {
 				loggerInfra := infraMock.NewMockLog(ctrl)
-				loggerInfra.EXPECT().Error(err).Return()
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
 				return loggerInfra
 			},
 			articleRepoMock: func() *mock_article.MockArticle {
@@ -139,6 +153,18 @@ func TestService_Update(t *testing.T) {
 			error:   err,
 			ctx:     context.Background(),
 		},
+		{
+			name: "InvalidArticle",
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			article: entity.NewArticle(strings.Repeat("t", 51), "slug", nil),
+			error:   article.ErrValidation,
+			ctx:     context.Background(),
+		},
 	}
 
 	for _, test := range tests {
@@ -207,7 +233,7 @@ func TestService_Delete(t *testing.T) {
 			name: "RepoError",
 			loggerMock: func() *infraMock.MockLog {
 				loggerInfra := infraMock.NewMockLog(ctrl)
-				loggerInfra.EXPECT().Error(err).Return()
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
 				return loggerInfra
 			},
 			articleRepoMock: func() *mock_article.MockArticle {
@@ -290,7 +316,7 @@ func TestService_Detail(t *testing.T) {
 			name: "RepoError",
 			loggerMock: func() *infraMock.MockLog {
 				loggerInfra := infraMock.NewMockLog(ctrl)
-				loggerInfra.EXPECT().Error(err).Return()
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
 				return loggerInfra
 			},
 			articleRepoMock: func() *mock_article.MockArticle {
@@ -380,7 +406,7 @@ func TestService_List(t *testing.T) {
 			name: "RepoError",
 			loggerMock: func() *infraMock.MockLog {
 				loggerInfra := infraMock.NewMockLog(ctrl)
-				loggerInfra.EXPECT().Error(err).Return()
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
 				return loggerInfra
 			},
 			articleRepoMock: func() *mock_article.MockArticle {
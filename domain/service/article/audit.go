@@ -0,0 +1,40 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	loggerInfra "m1-article-service/infrastructure/log"
+)
+
+// AuditService reads the audit log of article writes.
+type AuditService struct {
+	auditLog article.AuditLog
+	logger   loggerInfra.Logger
+}
+
+func NewAuditService(logger loggerInfra.Logger, auditLog article.AuditLog) *AuditService {
+	return &AuditService{auditLog: auditLog, logger: logger}
+}
+
+// Audit returns a page of the entries matching the filter, most recent
+// first.
+func (s AuditService) Audit(ctx context.Context, filter article.AuditFilter, page uint16) ([]*entity.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "article.AuditService.Audit", trace.WithAttributes(attribute.Int("page", int(page))))
+	defer span.End()
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, fmt.Errorf("%w: the end of the time range is before its start", article.ErrValidation)
+	}
+	entries, err := s.auditLog.Audit(ctx, filter, page)
+	if err != nil {
+		s.logger.Error(ctx, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return entries, nil
+}
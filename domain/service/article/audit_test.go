@@ -0,0 +1,39 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/memory"
+	"m1-article-service/infrastructure/principal"
+	infraMock "m1-article-service/mock/infrastructure"
+	"testing"
+	"time"
+)
+
+func TestAuditService_Audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := memory.NewArticleRepository()
+	s := NewAuditService(infraMock.NewMockLog(ctrl), repo)
+
+	ctx := principal.NewContext(context.Background(), "alice")
+	id, err := NewService(infraMock.NewMockLog(ctrl), repo).Create(ctx, entity.NewArticle("title", "slug", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := s.Audit(context.Background(), article.AuditFilter{Principal: "alice"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ArticleID != id || entries[0].Action != entity.AuditCreate {
+		t.Errorf("entries = %+v", entries)
+	}
+
+	now := time.Now()
+	_, err = s.Audit(context.Background(), article.AuditFilter{From: now, To: now.Add(-time.Second)}, 1)
+	if !errors.Is(err, article.ErrValidation) {
+		t.Errorf("reversed time range error = %v, want ErrValidation", err)
+	}
+}
@@ -0,0 +1,80 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+)
+
+// MaxBatchSize bounds the number of items of a single batch call.
+const MaxBatchSize = 100
+
+func (s Service) BatchGet(ctx context.Context, ids []int64) ([]*entity.Article, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.BatchGet", trace.WithAttributes(attribute.Int("batch.size", len(ids))))
+	defer span.End()
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	articles, err := s.articleRepository.BatchDetail(ctx, ids)
+	if err != nil {
+		s.fail(ctx, span, err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// BatchCreate validates every article before writing any of them; when one
+// is invalid nothing is written and the results explain why.
+func (s Service) BatchCreate(ctx context.Context, articles []*entity.Article) ([]article.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.BatchCreate", trace.WithAttributes(attribute.Int("batch.size", len(articles))))
+	defer span.End()
+	if err := checkBatchSize(len(articles)); err != nil {
+		return nil, err
+	}
+
+	results := make([]article.BatchResult, len(articles))
+	valid := true
+	for i, a := range articles {
+		results[i].Err = Validate(a)
+		valid = valid && results[i].Err == nil
+	}
+	if !valid {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = article.ErrBatchAborted
+			}
+		}
+		return results, nil
+	}
+
+	results, err := s.articleRepository.BatchCreate(ctx, articles)
+	if err != nil {
+		s.fail(ctx, span, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s Service) BatchDelete(ctx context.Context, ids []int64) ([]article.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.BatchDelete", trace.WithAttributes(attribute.Int("batch.size", len(ids))))
+	defer span.End()
+	if err := checkBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+	results, err := s.articleRepository.BatchDelete(ctx, ids)
+	if err != nil {
+		s.fail(ctx, span, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 || n > MaxBatchSize {
+		return fmt.Errorf("%w: batch must have between 1 and %d items", article.ErrValidation, MaxBatchSize)
+	}
+	return nil
+}
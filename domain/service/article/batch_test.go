@@ -0,0 +1,158 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	infraMock "m1-article-service/mock/infrastructure"
+	mock_article "m1-article-service/mock/repository"
+	"testing"
+)
+
+func TestService_BatchGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() {
+		ctrl.Finish()
+	})
+	err := errors.New("error")
+
+	var tests = []struct {
+		name            string
+		ids             []int64
+		loggerMock      func() *infraMock.MockLog
+		articleRepoMock func() *mock_article.MockArticle
+		error           error
+	}{
+		{
+			name: "success",
+			ids:  []int64{2, 1},
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().BatchDetail(gomock.Any(), []int64{2, 1}).Return([]*entity.Article{{ID: 2}, nil}, nil)
+				return repo
+			},
+		},
+		{
+			name: "Empty",
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			error: article.ErrValidation,
+		},
+		{
+			name: "TooMany",
+			ids:  make([]int64, MaxBatchSize+1),
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			error: article.ErrValidation,
+		},
+		{
+			name: "RepoError",
+			ids:  []int64{1},
+			loggerMock: func() *infraMock.MockLog {
+				loggerInfra := infraMock.NewMockLog(ctrl)
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
+				return loggerInfra
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().BatchDetail(gomock.Any(), gomock.Any()).Return(nil, err)
+				return repo
+			},
+			error: err,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewService(test.loggerMock(), test.articleRepoMock())
+			articles, err := service.BatchGet(context.Background(), test.ids)
+			if !errors.Is(err, test.error) {
+				t.Fatalf("error = %v, want %v", err, test.error)
+			}
+			if err == nil && len(articles) != len(test.ids) {
+				t.Errorf("got %d articles, want %d", len(articles), len(test.ids))
+			}
+		})
+	}
+}
+
+func TestService_BatchCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() {
+		ctrl.Finish()
+	})
+
+	var tests = []struct {
+		name            string
+		articles        []*entity.Article
+		articleRepoMock func() *mock_article.MockArticle
+		errors          []error
+	}{
+		{
+			name:     "success",
+			articles: []*entity.Article{entity.NewArticle("a", "a", nil), entity.NewArticle("b", "b", nil)},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().BatchCreate(gomock.Any(), gomock.Len(2)).
+					Return([]article.BatchResult{{ID: 1}, {ID: 2}}, nil)
+				return repo
+			},
+			errors: []error{nil, nil},
+		},
+		{
+			name:     "InvalidItem",
+			articles: []*entity.Article{entity.NewArticle("a", "a", nil), entity.NewArticle("", "b", nil)},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			errors: []error{article.ErrBatchAborted, article.ErrValidation},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewService(infraMock.NewMockLog(ctrl), test.articleRepoMock())
+			results, err := service.BatchCreate(context.Background(), test.articles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range test.errors {
+				if !errors.Is(results[i].Err, want) {
+					t.Errorf("result %d error = %v, want %v", i, results[i].Err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestService_BatchDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() {
+		ctrl.Finish()
+	})
+	repo := mock_article.NewMockArticle(ctrl)
+	repo.EXPECT().BatchDelete(gomock.Any(), []int64{1, 2}).
+		Return([]article.BatchResult{{ID: 1, Err: article.ErrBatchAborted}, {ID: 2, Err: article.ErrNotFound}}, nil)
+
+	service := NewService(infraMock.NewMockLog(ctrl), repo)
+	results, err := service.BatchDelete(context.Background(), []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article.BatchApplied(results) {
+		t.Error("batch with a missing article reported as applied")
+	}
+}
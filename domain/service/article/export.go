@@ -0,0 +1,26 @@
+package article
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+)
+
+// Export passes every article matching filter to fn without holding the
+// result set in memory.
+func (s Service) Export(ctx context.Context, filter article.ExportFilter, fn func(*entity.Article) error) error {
+	ctx, span := tracer.Start(ctx, "article.Service.Export")
+	defer span.End()
+	var exported int
+	err := s.articleRepository.Export(ctx, filter, func(a *entity.Article) error {
+		exported++
+		return fn(a)
+	})
+	span.SetAttributes(attribute.Int("export.articles", exported))
+	if err != nil {
+		s.fail(ctx, span, err)
+		return err
+	}
+	return nil
+}
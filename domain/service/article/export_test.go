@@ -0,0 +1,88 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	infraMock "m1-article-service/mock/infrastructure"
+	mock_article "m1-article-service/mock/repository"
+	"testing"
+)
+
+func TestService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() {
+		ctrl.Finish()
+	})
+	err := errors.New("error")
+	filter := article.ExportFilter{Tag: "go"}
+	stored := []*entity.Article{entity.NewArticle("a", "a", nil), entity.NewArticle("b", "b", nil)}
+	export := func(_ context.Context, _ article.ExportFilter, fn func(*entity.Article) error) error {
+		for _, a := range stored {
+			if err := fn(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var tests = []struct {
+		name            string
+		fn              func(*entity.Article) error
+		loggerMock      func() *infraMock.MockLog
+		articleRepoMock func() *mock_article.MockArticle
+		exported        int
+		error           error
+	}{
+		{
+			name: "success",
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().Export(gomock.Any(), filter, gomock.Any()).DoAndReturn(export)
+				return repo
+			},
+			exported: 2,
+		},
+		{
+			name: "CallbackError",
+			fn:   func(*entity.Article) error { return err },
+			loggerMock: func() *infraMock.MockLog {
+				loggerInfra := infraMock.NewMockLog(ctrl)
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
+				return loggerInfra
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().Export(gomock.Any(), filter, gomock.Any()).DoAndReturn(export)
+				return repo
+			},
+			exported: 1,
+			error:    err,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewService(test.loggerMock(), test.articleRepoMock())
+			var exported int
+			err := service.Export(context.Background(), filter, func(a *entity.Article) error {
+				exported++
+				if test.fn != nil {
+					return test.fn(a)
+				}
+				return nil
+			})
+			if !errors.Is(err, test.error) {
+				t.Errorf("error = %v, want %v", err, test.error)
+			}
+			if exported != test.exported {
+				t.Errorf("exported %d articles, want %d", exported, test.exported)
+			}
+		})
+	}
+}
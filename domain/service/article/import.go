@@ -0,0 +1,121 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"time"
+)
+
+const DefaultImportBatchSize = 500
+
+// ImportSource yields the articles to import. Next returns io.EOF after the
+// last record; an error wrapping article.ErrValidation marks a single bad
+// record which is reported and skipped, any other error aborts the import.
+type ImportSource interface {
+	Next() (*entity.Article, error)
+}
+
+type ImportOptions struct {
+	article.ImportOptions
+	BatchSize int
+}
+
+// RowError is a record that was not imported; Row is 1-based.
+type RowError struct {
+	Row int
+	Err error
+}
+
+type ImportReport struct {
+	Created int
+	Updated int
+	Failed  int
+	Errors  []RowError
+}
+
+// Import validates every record of source and writes the valid ones in
+// batches. Articles without a creation time are stamped with the current one
+// and articles without a status are published.
+func (s Service) Import(ctx context.Context, source ImportSource, opts ImportOptions) (*ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "article.Service.Import")
+	defer span.End()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultImportBatchSize
+	}
+
+	report := &ImportReport{}
+	batch := make([]*entity.Article, 0, opts.BatchSize)
+	rows := make([]int, 0, opts.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := s.articleRepository.Import(ctx, batch, opts.ImportOptions)
+		if err != nil {
+			return err
+		}
+		for i, res := range results {
+			switch {
+			case res.Err != nil:
+				report.fail(rows[i], res.Err)
+			case res.Updated:
+				report.Updated++
+			default:
+				report.Created++
+			}
+		}
+		batch, rows = batch[:0], rows[:0]
+		return nil
+	}
+
+	for row := 1; ; row++ {
+		a, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if errors.Is(err, article.ErrValidation) {
+			report.fail(row, err)
+			continue
+		} else if err != nil {
+			s.fail(ctx, span, err)
+			return report, err
+		}
+		if err := Validate(a); err != nil {
+			report.fail(row, err)
+			continue
+		}
+		if a.CreatedAt == 0 {
+			a.CreatedAt = uint64(time.Now().Unix())
+		}
+		if a.Status == "" {
+			a.Status = entity.StatusPublished
+		}
+		batch = append(batch, a)
+		rows = append(rows, row)
+		if len(batch) == opts.BatchSize {
+			if err := flush(); err != nil {
+				s.fail(ctx, span, err)
+				return report, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		s.fail(ctx, span, err)
+		return report, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.created", report.Created),
+		attribute.Int("import.updated", report.Updated),
+		attribute.Int("import.failed", report.Failed),
+	)
+	return report, nil
+}
+
+func (r *ImportReport) fail(row int, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, RowError{Row: row, Err: err})
+}
@@ -0,0 +1,166 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	infraMock "m1-article-service/mock/infrastructure"
+	mock_article "m1-article-service/mock/repository"
+	"testing"
+)
+
+// sliceSource yields the given articles or errors in order.
+type sliceSource struct {
+	items []any
+}
+
+func (s *sliceSource) Next() (*entity.Article, error) {
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	if err, ok := item.(error); ok {
+		return nil, err
+	}
+	return item.(*entity.Article), nil
+}
+
+func TestService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() {
+		ctrl.Finish()
+	})
+	err := errors.New("error")
+	valid := func(slug string) *entity.Article { return entity.NewArticle("title", slug, nil) }
+
+	var tests = []struct {
+		name            string
+		items           []any
+		opts            ImportOptions
+		loggerMock      func() *infraMock.MockLog
+		articleRepoMock func() *mock_article.MockArticle
+		report          ImportReport
+		failedRows      []int
+		error           error
+	}{
+		{
+			name:  "batches",
+			items: []any{valid("a"), valid("b"), valid("c")},
+			opts:  ImportOptions{BatchSize: 2, ImportOptions: article.ImportOptions{UpsertBySlug: true}},
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				opts := article.ImportOptions{UpsertBySlug: true}
+				gomock.InOrder(
+					repo.EXPECT().Import(gomock.Any(), gomock.Len(2), opts).
+						Return([]article.ImportResult{{}, {Updated: true}}, nil),
+					repo.EXPECT().Import(gomock.Any(), gomock.Len(1), opts).
+						Return([]article.ImportResult{{Err: article.ErrAlreadyExist}}, nil),
+				)
+				return repo
+			},
+			report:     ImportReport{Created: 1, Updated: 1, Failed: 1},
+			failedRows: []int{3},
+		},
+		{
+			name: "InvalidRows",
+			items: []any{
+				valid("a"),
+				entity.NewArticle("", "b", nil),
+				article.ErrValidation,
+				entity.NewArticle("title", "c", []string{"a tag that is far longer than thirty characters"}),
+			},
+			loggerMock: func() *infraMock.MockLog {
+				return infraMock.NewMockLog(ctrl)
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().Import(gomock.Any(), gomock.Len(1), gomock.Any()).
+					Return([]article.ImportResult{{}}, nil)
+				return repo
+			},
+			report:     ImportReport{Created: 1, Failed: 3},
+			failedRows: []int{2, 3, 4},
+		},
+		{
+			name:  "SourceError",
+			items: []any{valid("a"), err},
+			loggerMock: func() *infraMock.MockLog {
+				loggerInfra := infraMock.NewMockLog(ctrl)
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
+				return loggerInfra
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				return mock_article.NewMockArticle(ctrl)
+			},
+			error: err,
+		},
+		{
+			name:  "RepoError",
+			items: []any{valid("a")},
+			loggerMock: func() *infraMock.MockLog {
+				loggerInfra := infraMock.NewMockLog(ctrl)
+				loggerInfra.EXPECT().Error(gomock.Any(), err).Return()
+				return loggerInfra
+			},
+			articleRepoMock: func() *mock_article.MockArticle {
+				repo := mock_article.NewMockArticle(ctrl)
+				repo.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, err)
+				return repo
+			},
+			error: err,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := NewService(test.loggerMock(), test.articleRepoMock())
+			report, err := service.Import(context.Background(), &sliceSource{items: test.items}, test.opts)
+			if !errors.Is(err, test.error) {
+				t.Fatalf("error = %v, want %v", err, test.error)
+			}
+			if test.error != nil {
+				return
+			}
+			if report.Created != test.report.Created || report.Updated != test.report.Updated || report.Failed != test.report.Failed {
+				t.Errorf("report = %+v, want %+v", *report, test.report)
+			}
+			for i, row := range test.failedRows {
+				if report.Errors[i].Row != row {
+					t.Errorf("error %d is for row %d, want %d", i, report.Errors[i].Row, row)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	long := string(make([]rune, 51))
+	tests := []struct {
+		name    string
+		article *entity.Article
+		valid   bool
+	}{
+		{"valid", entity.NewArticle("title", "slug", []string{"go"}), true},
+		{"MissingTitle", entity.NewArticle("", "slug", nil), false},
+		{"LongTitle", entity.NewArticle(long, "slug", nil), false},
+		{"MissingSlug", entity.NewArticle("title", "", nil), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.article)
+			if test.valid && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if !test.valid && !errors.Is(err, article.ErrValidation) {
+				t.Errorf("error = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package article
+
+import (
+	"errors"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"unicode/utf8"
+)
+
+// Limits of the articles table columns.
+const (
+	maxTitleLength = 50
+	maxSlugLength  = 100
+	maxTagLength   = 30
+)
+
+// Validate checks a in the same way the database would, so that bad rows
+// are rejected before a write. The returned error wraps article.ErrValidation.
+func Validate(a *entity.Article) error {
+	var errs []error
+	switch n := utf8.RuneCountInString(a.Title); {
+	case n == 0:
+		errs = append(errs, errors.New("title is required"))
+	case n > maxTitleLength:
+		errs = append(errs, fmt.Errorf("title is longer than %d characters", maxTitleLength))
+	}
+	switch n := utf8.RuneCountInString(a.Slug); {
+	case n == 0:
+		errs = append(errs, errors.New("slug is required"))
+	case n > maxSlugLength:
+		errs = append(errs, fmt.Errorf("slug is longer than %d characters", maxSlugLength))
+	}
+	for _, tag := range a.Tags {
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			errs = append(errs, fmt.Errorf("tag %q is longer than %d characters", tag, maxTagLength))
+		}
+	}
+	switch a.Status {
+	case "", entity.StatusDraft, entity.StatusPublished:
+	default:
+		errs = append(errs, fmt.Errorf("unknown status %q", a.Status))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", article.ErrValidation, errors.Join(errs...))
+}
@@ -0,0 +1,208 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	loggerInfra "m1-article-service/infrastructure/log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSubscriberTooSlow ends a Watch whose subscriber did not keep up
+	// with the events; it should resume after the last event it received.
+	ErrSubscriberTooSlow = errors.New("subscriber too slow")
+	// ErrFeedUnavailable ends or refuses a Watch while the hub does not
+	// receive events.
+	ErrFeedUnavailable = errors.New("article event feed unavailable")
+)
+
+const replayPageSize = 100
+
+type HubConfig struct {
+	// Buffer is the number of events a subscriber may lag behind before
+	// it is disconnected.
+	Buffer int
+	// Retention is how long events can be replayed; zero keeps them.
+	Retention     time.Duration
+	RetryInterval time.Duration
+}
+
+func (c HubConfig) withDefaults() HubConfig {
+	if c.Buffer <= 0 {
+		c.Buffer = 256
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = time.Second
+	}
+	return c
+}
+
+// Hub shares one listener of the change feed between all watchers.
+type Hub struct {
+	changes article.Changes
+	logger  loggerInfra.Logger
+	cfg     HubConfig
+
+	mu          sync.Mutex
+	listening   bool
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan *entity.Event
+	// err is set before events is closed.
+	err error
+}
+
+func NewHub(changes article.Changes, logger loggerInfra.Logger, cfg HubConfig) *Hub {
+	return &Hub{
+		changes:     changes,
+		logger:      logger,
+		cfg:         cfg.withDefaults(),
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// Run listens to the change feed until ctx is done, listening again after
+// failures, and prunes the events older than the retention period.
+// Watchers are disconnected whenever the feed fails since they may have
+// missed events.
+func (h *Hub) Run(ctx context.Context) error {
+	if h.cfg.Retention > 0 {
+		go h.prune(ctx)
+	}
+	for {
+		err := h.changes.Listen(ctx, h.ready, h.broadcast)
+		h.disconnectAll(ErrFeedUnavailable)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			err = errors.New("listener stopped")
+		}
+		h.logger.Error(ctx, fmt.Errorf("listening to article events: %w", err))
+
+		timer := time.NewTimer(h.cfg.RetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch calls fn with the retained events after the sequence number after,
+// then with every new event, until ctx is done, fn fails or the subscriber
+// is disconnected. An after of zero skips the replay; one whose following
+// events were pruned fails with article.ErrPruned.
+func (h *Hub) Watch(ctx context.Context, after int64, fn func(*entity.Event) error) error {
+	sub := &subscriber{events: make(chan *entity.Event, h.cfg.Buffer)}
+	h.mu.Lock()
+	if !h.listening {
+		h.mu.Unlock()
+		return ErrFeedUnavailable
+	}
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	defer h.unsubscribe(sub)
+
+	// Subscribing first means that the events committed during the replay
+	// are buffered; those already replayed are skipped below.
+	last := after
+	for after > 0 {
+		events, err := h.changes.Since(ctx, last, replayPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				return err
+			}
+			last = e.ID
+		}
+		if len(events) < replayPageSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-sub.events:
+			if !ok {
+				return sub.err
+			}
+			// Events are numbered once committed, so a live event with
+			// an ID up to last was replayed rather than committed since.
+			if e.ID <= last {
+				continue
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+			last = e.ID
+		}
+	}
+}
+
+func (h *Hub) ready() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listening = true
+}
+
+// broadcast never blocks the feed: a subscriber whose buffer is full is
+// disconnected instead.
+func (h *Hub) broadcast(e *entity.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- e:
+		default:
+			h.drop(sub, ErrSubscriberTooSlow)
+		}
+	}
+}
+
+func (h *Hub) disconnectAll(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listening = false
+	for sub := range h.subscribers {
+		h.drop(sub, err)
+	}
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+}
+
+func (h *Hub) drop(sub *subscriber, err error) {
+	delete(h.subscribers, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+func (h *Hub) prune(ctx context.Context) {
+	ticker := time.NewTicker(min(h.cfg.Retention, time.Hour))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := h.changes.Prune(ctx, time.Now().Add(-h.cfg.Retention)); err != nil && ctx.Err() == nil {
+				h.logger.Error(ctx, fmt.Errorf("pruning article events: %w", err))
+			}
+		}
+	}
+}
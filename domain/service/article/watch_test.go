@@ -0,0 +1,153 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"m1-article-service/domain/repository/article/memory"
+	infraMock "m1-article-service/mock/infrastructure"
+	mock_article "m1-article-service/mock/repository"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// startHub runs h until the test ends and waits for it to listen.
+func startHub(t *testing.T, h *Hub) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		h.Run(ctx)
+		close(stopped)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		h.mu.Lock()
+		listening := h.listening
+		h.mu.Unlock()
+		if listening {
+			return
+		}
+	}
+	t.Fatal("hub is not listening")
+}
+
+// waitSubscribed waits for a Watch started in another goroutine to
+// subscribe.
+func waitSubscribed(t *testing.T, h *Hub) {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		h.mu.Lock()
+		subscribed := len(h.subscribers) > 0
+		h.mu.Unlock()
+		if subscribed {
+			return
+		}
+	}
+	t.Fatal("watch did not subscribe")
+}
+
+func createArticles(t *testing.T, repo *memory.ArticleRepository, titles ...string) {
+	for _, title := range titles {
+		if _, err := repo.Create(context.Background(), entity.NewArticle(title, title, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHub_ReplayThenLive(t *testing.T) {
+	repo := memory.NewArticleRepository()
+	createArticles(t, repo, "a", "b")
+	hub := NewHub(repo, infraMock.NewMockLog(gomock.NewController(t)), HubConfig{})
+	startHub(t, hub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []int64
+	err := hub.Watch(ctx, 2, func(e *entity.Event) error {
+		got = append(got, e.ID)
+		switch len(got) {
+		case 2:
+			// the replay is done, the next events are live
+			createArticles(t, repo, "c")
+		case 4:
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("watch = %v, want context.Canceled", err)
+	}
+	if want := []int64{3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+}
+
+func TestHub_Pruned(t *testing.T) {
+	repo := memory.NewArticleRepository()
+	createArticles(t, repo, "a")
+	if err := repo.Prune(context.Background(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	hub := NewHub(repo, infraMock.NewMockLog(gomock.NewController(t)), HubConfig{})
+	startHub(t, hub)
+
+	err := hub.Watch(context.Background(), 1, func(*entity.Event) error { return nil })
+	if !errors.Is(err, article.ErrPruned) {
+		t.Errorf("watch = %v, want ErrPruned", err)
+	}
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	repo := memory.NewArticleRepository()
+	hub := NewHub(repo, infraMock.NewMockLog(gomock.NewController(t)), HubConfig{Buffer: 2})
+	startHub(t, hub)
+
+	unblock := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- hub.Watch(context.Background(), 0, func(*entity.Event) error {
+			<-unblock
+			return nil
+		})
+	}()
+	waitSubscribed(t, hub)
+	createArticles(t, repo, "a", "b", "c")
+	close(unblock)
+	if err := <-done; !errors.Is(err, ErrSubscriberTooSlow) {
+		t.Errorf("watch = %v, want ErrSubscriberTooSlow", err)
+	}
+}
+
+func TestHub_FeedFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	changesMock := mock_article.NewMockChanges(ctrl)
+
+	hub := NewHub(changesMock, loggerMock, HubConfig{RetryInterval: time.Hour})
+	if err := hub.Watch(context.Background(), 0, nil); !errors.Is(err, ErrFeedUnavailable) {
+		t.Fatalf("watch before listening = %v, want ErrFeedUnavailable", err)
+	}
+
+	fail := make(chan struct{})
+	changesMock.EXPECT().Listen(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ready func(), _ func(*entity.Event)) error {
+			ready()
+			<-fail
+			return errors.New("connection lost")
+		})
+	loggerMock.EXPECT().Error(gomock.Any(), gomock.Any())
+	startHub(t, hub)
+
+	done := make(chan error)
+	go func() { done <- hub.Watch(context.Background(), 0, nil) }()
+	waitSubscribed(t, hub)
+	close(fail)
+	if err := <-done; !errors.Is(err, ErrFeedUnavailable) {
+		t.Errorf("watch after feed failure = %v, want ErrFeedUnavailable", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Package idempotency runs client requests at most once per idempotency
+// key, replaying the stored response when the request is retried.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"m1-article-service/domain/repository/idempotency"
+	loggerInfra "m1-article-service/infrastructure/log"
+	"time"
+)
+
+var (
+	// ErrKeyReused is returned when a key is retried with another request.
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrInProgress is returned when a key is retried while its first
+	// request still runs.
+	ErrInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+const pruneInterval = time.Minute
+
+type Config struct {
+	// TTL is how long the response of a key is kept.
+	TTL time.Duration
+	// Lease is how long a key stays reserved by a request that neither
+	// completed nor failed, e.g. because its replica crashed.
+	Lease time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.TTL <= 0 {
+		c.TTL = 24 * time.Hour
+	}
+	if c.Lease <= 0 {
+		c.Lease = time.Minute
+	}
+	return c
+}
+
+type Service struct {
+	store  idempotency.Store
+	logger loggerInfra.Logger
+	cfg    Config
+	now    func() time.Time
+}
+
+func NewService(store idempotency.Store, logger loggerInfra.Logger, cfg Config) *Service {
+	return &Service{store: store, logger: logger, cfg: cfg.withDefaults(), now: time.Now}
+}
+
+// Do runs fn once for key and stores its response. A retry with the same
+// hash returns the stored response with replayed set instead of running
+// fn again; a retry with another hash fails with ErrKeyReused. When fn
+// fails, the key is released and the error returned, so the request can be
+// retried.
+func (s *Service) Do(ctx context.Context, key, hash string, fn func(context.Context) ([]byte, error)) (response []byte, replayed bool, err error) {
+	now := s.now()
+	record, err := s.store.Reserve(ctx, key, hash, now, now.Add(s.cfg.Lease))
+	if err != nil {
+		return nil, false, err
+	}
+	if record != nil {
+		switch {
+		case record.Hash != hash:
+			return nil, false, ErrKeyReused
+		case record.Response == nil:
+			return nil, false, ErrInProgress
+		}
+		return record.Response, true, nil
+	}
+
+	response, err = fn(ctx)
+	if err != nil {
+		if releaseErr := s.store.Release(context.WithoutCancel(ctx), key); releaseErr != nil {
+			s.logger.Error(ctx, fmt.Errorf("releasing idempotency key: %w", releaseErr))
+		}
+		return nil, false, err
+	}
+	// The request took effect, so a failure to store its response must not
+	// fail it; a retry then waits for the lease to expire.
+	completed, err := s.store.Complete(context.WithoutCancel(ctx), key, hash, response, s.now().Add(s.cfg.TTL))
+	if err != nil {
+		s.logger.Error(ctx, fmt.Errorf("storing idempotent response: %w", err))
+	} else if !completed {
+		s.logger.Warning(ctx, "idempotency key lease expired before the request completed, response not stored",
+			"lease", s.cfg.Lease)
+	}
+	return response, false, nil
+}
+
+// Run prunes the expired keys every minute until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			pruned, err := s.store.Prune(ctx, s.now())
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Error(ctx, fmt.Errorf("pruning idempotency keys: %w", err))
+				}
+				continue
+			}
+			if pruned > 0 {
+				s.logger.Debug(ctx, "idempotency keys pruned", "count", pruned)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/repository/idempotency/memory"
+	infraMock "m1-article-service/mock/infrastructure"
+	"testing"
+	"time"
+)
+
+func TestService_Do(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(memory.NewStore(), infraMock.NewMockLog(ctrl), Config{})
+	ctx := context.Background()
+	runs := 0
+	create := func(context.Context) ([]byte, error) {
+		runs++
+		return []byte("created"), nil
+	}
+
+	response, replayed, err := s.Do(ctx, "key", "hash", create)
+	if err != nil || replayed || string(response) != "created" {
+		t.Fatalf("first Do = %q, %v, %v", response, replayed, err)
+	}
+	response, replayed, err = s.Do(ctx, "key", "hash", create)
+	if err != nil || !replayed || string(response) != "created" {
+		t.Fatalf("retried Do = %q, %v, %v", response, replayed, err)
+	}
+	if runs != 1 {
+		t.Errorf("fn ran %d times, want 1", runs)
+	}
+	if _, _, err := s.Do(ctx, "key", "other", create); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Do with another request error = %v, want ErrKeyReused", err)
+	}
+}
+
+func TestService_DoInProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(memory.NewStore(), infraMock.NewMockLog(ctrl), Config{})
+	ctx := context.Background()
+	_, _, err := s.Do(ctx, "key", "hash", func(ctx context.Context) ([]byte, error) {
+		_, _, err := s.Do(ctx, "key", "hash", func(context.Context) ([]byte, error) {
+			t.Error("concurrent request ran")
+			return nil, nil
+		})
+		if !errors.Is(err, ErrInProgress) {
+			t.Errorf("concurrent Do error = %v, want ErrInProgress", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_DoReleasesFailedKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(memory.NewStore(), infraMock.NewMockLog(ctrl), Config{})
+	ctx := context.Background()
+	failure := errors.New("failure")
+	if _, _, err := s.Do(ctx, "key", "hash", func(context.Context) ([]byte, error) { return nil, failure }); !errors.Is(err, failure) {
+		t.Fatalf("Do error = %v, want %v", err, failure)
+	}
+	response, replayed, err := s.Do(ctx, "key", "other", func(context.Context) ([]byte, error) { return []byte("ok"), nil })
+	if err != nil || replayed || string(response) != "ok" {
+		t.Fatalf("Do after failure = %q, %v, %v", response, replayed, err)
+	}
+}
+
+func TestService_DoExpiredKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(memory.NewStore(), infraMock.NewMockLog(ctrl), Config{TTL: time.Hour})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+	if _, _, err := s.Do(ctx, "key", "hash", func(context.Context) ([]byte, error) { return []byte("first"), nil }); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Hour)
+	response, replayed, err := s.Do(ctx, "key", "other", func(context.Context) ([]byte, error) { return []byte("second"), nil })
+	if err != nil || replayed || string(response) != "second" {
+		t.Fatalf("Do after expiry = %q, %v, %v", response, replayed, err)
+	}
+}
+
+func TestService_DoLeaseExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := infraMock.NewMockLog(ctrl)
+	s := NewService(memory.NewStore(), logger, Config{Lease: time.Minute})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	logger.EXPECT().Warning(gomock.Any(), "idempotency key lease expired before the request completed, response not stored", gomock.Any())
+	_, _, err := s.Do(ctx, "key", "hash", func(ctx context.Context) ([]byte, error) {
+		// the request outlives its lease and another one takes the key over
+		now = now.Add(time.Minute)
+		if _, _, err := s.Do(ctx, "key", "other", func(context.Context) ([]byte, error) { return []byte("second"), nil }); err != nil {
+			t.Fatal(err)
+		}
+		return []byte("first"), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, replayed, err := s.Do(ctx, "key", "other", func(context.Context) ([]byte, error) {
+		t.Error("completed request ran again")
+		return nil, nil
+	})
+	if err != nil || !replayed || string(response) != "second" {
+		t.Fatalf("retried Do = %q, %v, %v; the late response must not overwrite the key", response, replayed, err)
+	}
+}
@@ -0,0 +1,106 @@
+// Package webhook manages the webhook subscriptions of partners and the
+// log of their deliveries.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	loggerInfra "m1-article-service/infrastructure/log"
+	"net/url"
+	"slices"
+)
+
+// EventTypes are the event types a webhook can subscribe to.
+var EventTypes = []string{
+	entity.EventArticleCreated,
+	entity.EventArticleUpdated,
+	entity.EventArticlePublished,
+	entity.EventArticleDeleted,
+}
+
+type Service struct {
+	webhookRepository webhook.Webhook
+	logger            loggerInfra.Logger
+}
+
+func NewService(logger loggerInfra.Logger, webhookRepo webhook.Webhook) *Service {
+	return &Service{webhookRepository: webhookRepo, logger: logger}
+}
+
+// Create stores a valid webhook, generating its secret when it has none.
+func (s Service) Create(ctx context.Context, w *entity.Webhook) (int64, error) {
+	if err := Validate(w); err != nil {
+		return 0, err
+	}
+	if w.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return 0, err
+		}
+		w.Secret = hex.EncodeToString(secret)
+	}
+	id, err := s.webhookRepository.Create(ctx, w)
+	if err != nil {
+		s.logger.Error(ctx, err)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s Service) List(ctx context.Context) ([]*entity.Webhook, error) {
+	return s.webhookRepository.List(ctx)
+}
+
+func (s Service) Delete(ctx context.Context, id int64) error {
+	return s.webhookRepository.Delete(ctx, id)
+}
+
+func (s Service) Deliveries(ctx context.Context, filter webhook.DeliveryFilter, page uint16) ([]*entity.WebhookDelivery, error) {
+	return s.webhookRepository.Deliveries(ctx, filter, page)
+}
+
+// Delivery returns a delivery with the log of its attempts.
+func (s Service) Delivery(ctx context.Context, id int64) (*entity.WebhookDelivery, []entity.WebhookAttempt, error) {
+	d, err := s.webhookRepository.Delivery(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := s.webhookRepository.Attempts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, attempts, nil
+}
+
+// Replay schedules dead deliveries of a webhook again, see
+// webhook.Webhook.Replay.
+func (s Service) Replay(ctx context.Context, webhookID int64, ids []int64) (int64, error) {
+	if _, err := s.webhookRepository.Get(ctx, webhookID); err != nil {
+		return 0, err
+	}
+	replayed, err := s.webhookRepository.Replay(ctx, webhookID, ids)
+	if err != nil {
+		return 0, err
+	}
+	s.logger.Info(ctx, "webhook deliveries replayed", "webhook_id", webhookID, "count", replayed)
+	return replayed, nil
+}
+
+// Validate checks that a webhook has an absolute http or https URL and only
+// known event types. The error wraps webhook.ErrValidation.
+func Validate(w *entity.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", webhook.ErrValidation)
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(EventTypes, event) {
+			return fmt.Errorf("%w: unknown event type %q", webhook.ErrValidation, event)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	"m1-article-service/domain/repository/webhook/memory"
+	infraMock "m1-article-service/mock/infrastructure"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook entity.Webhook
+		valid   bool
+	}{
+		{"every event", entity.Webhook{URL: "https://example.com/hook"}, true},
+		{"some events", entity.Webhook{URL: "http://localhost:9000", Events: []string{entity.EventArticleCreated, entity.EventArticleDeleted}}, true},
+		{"no url", entity.Webhook{}, false},
+		{"relative url", entity.Webhook{URL: "/hook"}, false},
+		{"other scheme", entity.Webhook{URL: "ftp://example.com/hook"}, false},
+		{"unknown event", entity.Webhook{URL: "https://example.com/hook", Events: []string{"article.read"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.webhook)
+			if tt.valid && err != nil {
+				t.Errorf("Validate = %v", err)
+			}
+			if !tt.valid && !errors.Is(err, webhook.ErrValidation) {
+				t.Errorf("Validate = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestService_CreateGeneratesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(infraMock.NewMockLog(ctrl), memory.NewWebhookRepository())
+	ctx := context.Background()
+
+	generated := &entity.Webhook{URL: "https://example.com/a"}
+	if _, err := s.Create(ctx, generated); err != nil {
+		t.Fatal(err)
+	}
+	if len(generated.Secret) != 64 {
+		t.Errorf("generated secret %q, want 32 hex encoded bytes", generated.Secret)
+	}
+	other := &entity.Webhook{URL: "https://example.com/b"}
+	if _, err := s.Create(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if other.Secret == generated.Secret {
+		t.Error("two webhooks got the same secret")
+	}
+
+	given := &entity.Webhook{URL: "https://example.com/c", Secret: "shared"}
+	id, err := s.Create(ctx, given)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := s.webhookRepository.Get(ctx, id)
+	if stored.Secret != "shared" {
+		t.Errorf("stored secret %q, want the given one", stored.Secret)
+	}
+}
+
+func TestService_ReplayUnknownWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewService(infraMock.NewMockLog(ctrl), memory.NewWebhookRepository())
+	if _, err := s.Replay(context.Background(), 42, nil); !errors.Is(err, webhook.ErrNotFound) {
+		t.Errorf("Replay = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,684 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: article/v1/article.proto
+
+package articlev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ArticleService_Create_FullMethodName      = "/article.v1.ArticleService/Create"
+	ArticleService_Update_FullMethodName      = "/article.v1.ArticleService/Update"
+	ArticleService_Delete_FullMethodName      = "/article.v1.ArticleService/Delete"
+	ArticleService_Detail_FullMethodName      = "/article.v1.ArticleService/Detail"
+	ArticleService_List_FullMethodName        = "/article.v1.ArticleService/List"
+	ArticleService_Search_FullMethodName      = "/article.v1.ArticleService/Search"
+	ArticleService_Import_FullMethodName      = "/article.v1.ArticleService/Import"
+	ArticleService_Export_FullMethodName      = "/article.v1.ArticleService/Export"
+	ArticleService_BatchGet_FullMethodName    = "/article.v1.ArticleService/BatchGet"
+	ArticleService_BatchCreate_FullMethodName = "/article.v1.ArticleService/BatchCreate"
+	ArticleService_BatchDelete_FullMethodName = "/article.v1.ArticleService/BatchDelete"
+	ArticleService_Watch_FullMethodName       = "/article.v1.ArticleService/Watch"
+	ArticleService_ListAudit_FullMethodName   = "/article.v1.ArticleService/ListAudit"
+)
+
+// ArticleServiceClient is the client API for ArticleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ArticleServiceClient interface {
+	// Create accepts an idempotency-key metadata header: a retry with the same
+	// key and article returns the original response, with the
+	// idempotent-replayed header set, instead of creating it again.
+	Create(ctx context.Context, in *Article, opts ...grpc.CallOption) (*ArticleCreateResponse, error)
+	// Update replaces the title, slug and tags of the article: REST clients
+	// send the whole article, with PATCH or PUT.
+	Update(ctx context.Context, in *Article, opts ...grpc.CallOption) (*ArticleUpdateResponse, error)
+	Delete(ctx context.Context, in *ArticleID, opts ...grpc.CallOption) (*Empty, error)
+	Detail(ctx context.Context, in *ArticleID, opts ...grpc.CallOption) (*ArticleDetailResponse, error)
+	List(ctx context.Context, in *Pagination, opts ...grpc.CallOption) (*ArticleListResponse, error)
+	// Search returns the articles whose title, slug or tags contain every
+	// word of the query, best matches first.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*ArticleListResponse, error)
+	// Import creates articles from a stream; options are read from the first
+	// message. Invalid rows are reported in the response and skipped.
+	Import(ctx context.Context, opts ...grpc.CallOption) (ArticleService_ImportClient, error)
+	// Export streams every article matching the request from a single
+	// snapshot, ordered by ID.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ArticleService_ExportClient, error)
+	// BatchGet returns the articles in the order of the requested IDs.
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// BatchCreate and BatchDelete apply every item or none; Applied is false
+	// and the failing items carry their error when the batch was rejected.
+	BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// Watch streams article events as they are committed, in increasing
+	// sequence order. After a reconnect, pass the last sequence received to
+	// replay what was missed. A watcher that falls behind is disconnected
+	// with RESOURCE_EXHAUSTED and should reconnect the same way. Resuming
+	// after events that are no longer retained fails with OUT_OF_RANGE.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ArticleService_WatchClient, error)
+	// ListAudit returns a page of ten entries of the audit log of article
+	// writes, most recent first. Only the principals in ADMINS may call it.
+	ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (*ListAuditResponse, error)
+}
+
+type articleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArticleServiceClient(cc grpc.ClientConnInterface) ArticleServiceClient {
+	return &articleServiceClient{cc}
+}
+
+func (c *articleServiceClient) Create(ctx context.Context, in *Article, opts ...grpc.CallOption) (*ArticleCreateResponse, error) {
+	out := new(ArticleCreateResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Update(ctx context.Context, in *Article, opts ...grpc.CallOption) (*ArticleUpdateResponse, error) {
+	out := new(ArticleUpdateResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Delete(ctx context.Context, in *ArticleID, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ArticleService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Detail(ctx context.Context, in *ArticleID, opts ...grpc.CallOption) (*ArticleDetailResponse, error) {
+	out := new(ArticleDetailResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Detail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) List(ctx context.Context, in *Pagination, opts ...grpc.CallOption) (*ArticleListResponse, error) {
+	out := new(ArticleListResponse)
+	err := c.cc.Invoke(ctx, ArticleService_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*ArticleListResponse, error) {
+	out := new(ArticleListResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Search_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Import(ctx context.Context, opts ...grpc.CallOption) (ArticleService_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArticleService_ServiceDesc.Streams[0], ArticleService_Import_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &articleServiceImportClient{stream}
+	return x, nil
+}
+
+type ArticleService_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type articleServiceImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *articleServiceImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *articleServiceImportClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *articleServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ArticleService_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArticleService_ServiceDesc.Streams[1], ArticleService_Export_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &articleServiceExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArticleService_ExportClient interface {
+	Recv() (*Article, error)
+	grpc.ClientStream
+}
+
+type articleServiceExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *articleServiceExportClient) Recv() (*Article, error) {
+	m := new(Article)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *articleServiceClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, ArticleService_BatchGet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, ArticleService_BatchCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, ArticleService_BatchDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ArticleService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ArticleService_ServiceDesc.Streams[2], ArticleService_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &articleServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArticleService_WatchClient interface {
+	Recv() (*ArticleEvent, error)
+	grpc.ClientStream
+}
+
+type articleServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *articleServiceWatchClient) Recv() (*ArticleEvent, error) {
+	m := new(ArticleEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *articleServiceClient) ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (*ListAuditResponse, error) {
+	out := new(ListAuditResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListAudit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArticleServiceServer is the server API for ArticleService service.
+// All implementations must embed UnimplementedArticleServiceServer
+// for forward compatibility
+type ArticleServiceServer interface {
+	// Create accepts an idempotency-key metadata header: a retry with the same
+	// key and article returns the original response, with the
+	// idempotent-replayed header set, instead of creating it again.
+	Create(context.Context, *Article) (*ArticleCreateResponse, error)
+	// Update replaces the title, slug and tags of the article: REST clients
+	// send the whole article, with PATCH or PUT.
+	Update(context.Context, *Article) (*ArticleUpdateResponse, error)
+	Delete(context.Context, *ArticleID) (*Empty, error)
+	Detail(context.Context, *ArticleID) (*ArticleDetailResponse, error)
+	List(context.Context, *Pagination) (*ArticleListResponse, error)
+	// Search returns the articles whose title, slug or tags contain every
+	// word of the query, best matches first.
+	Search(context.Context, *SearchRequest) (*ArticleListResponse, error)
+	// Import creates articles from a stream; options are read from the first
+	// message. Invalid rows are reported in the response and skipped.
+	Import(ArticleService_ImportServer) error
+	// Export streams every article matching the request from a single
+	// snapshot, ordered by ID.
+	Export(*ExportRequest, ArticleService_ExportServer) error
+	// BatchGet returns the articles in the order of the requested IDs.
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// BatchCreate and BatchDelete apply every item or none; Applied is false
+	// and the failing items carry their error when the batch was rejected.
+	BatchCreate(context.Context, *BatchCreateRequest) (*BatchResponse, error)
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchResponse, error)
+	// Watch streams article events as they are committed, in increasing
+	// sequence order. After a reconnect, pass the last sequence received to
+	// replay what was missed. A watcher that falls behind is disconnected
+	// with RESOURCE_EXHAUSTED and should reconnect the same way. Resuming
+	// after events that are no longer retained fails with OUT_OF_RANGE.
+	Watch(*WatchRequest, ArticleService_WatchServer) error
+	// ListAudit returns a page of ten entries of the audit log of article
+	// writes, most recent first. Only the principals in ADMINS may call it.
+	ListAudit(context.Context, *ListAuditRequest) (*ListAuditResponse, error)
+	mustEmbedUnimplementedArticleServiceServer()
+}
+
+// UnimplementedArticleServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedArticleServiceServer struct {
+}
+
+func (UnimplementedArticleServiceServer) Create(context.Context, *Article) (*ArticleCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedArticleServiceServer) Update(context.Context, *Article) (*ArticleUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedArticleServiceServer) Delete(context.Context, *ArticleID) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedArticleServiceServer) Detail(context.Context, *ArticleID) (*ArticleDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Detail not implemented")
+}
+func (UnimplementedArticleServiceServer) List(context.Context, *Pagination) (*ArticleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedArticleServiceServer) Search(context.Context, *SearchRequest) (*ArticleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedArticleServiceServer) Import(ArticleService_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (UnimplementedArticleServiceServer) Export(*ExportRequest, ArticleService_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedArticleServiceServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedArticleServiceServer) BatchCreate(context.Context, *BatchCreateRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreate not implemented")
+}
+func (UnimplementedArticleServiceServer) BatchDelete(context.Context, *BatchDeleteRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (UnimplementedArticleServiceServer) Watch(*WatchRequest, ArticleService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedArticleServiceServer) ListAudit(context.Context, *ListAuditRequest) (*ListAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAudit not implemented")
+}
+func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
+
+// UnsafeArticleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArticleServiceServer will
+// result in compilation errors.
+type UnsafeArticleServiceServer interface {
+	mustEmbedUnimplementedArticleServiceServer()
+}
+
+func RegisterArticleServiceServer(s grpc.ServiceRegistrar, srv ArticleServiceServer) {
+	s.RegisterService(&ArticleService_ServiceDesc, srv)
+}
+
+func _ArticleService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Article)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Create(ctx, req.(*Article))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Article)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Update(ctx, req.(*Article))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArticleID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Delete(ctx, req.(*ArticleID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Detail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArticleID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Detail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Detail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Detail(ctx, req.(*ArticleID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Pagination)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).List(ctx, req.(*Pagination))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ArticleServiceServer).Import(&articleServiceImportServer{stream})
+}
+
+type ArticleService_ImportServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type articleServiceImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *articleServiceImportServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *articleServiceImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ArticleService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticleServiceServer).Export(m, &articleServiceExportServer{stream})
+}
+
+type ArticleService_ExportServer interface {
+	Send(*Article) error
+	grpc.ServerStream
+}
+
+type articleServiceExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *articleServiceExportServer) Send(m *Article) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArticleService_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_BatchGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_BatchCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).BatchCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_BatchCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).BatchCreate(ctx, req.(*BatchCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_BatchDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticleServiceServer).Watch(m, &articleServiceWatchServer{stream})
+}
+
+type ArticleService_WatchServer interface {
+	Send(*ArticleEvent) error
+	grpc.ServerStream
+}
+
+type articleServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *articleServiceWatchServer) Send(m *ArticleEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArticleService_ListAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListAudit(ctx, req.(*ListAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArticleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.ArticleService",
+	HandlerType: (*ArticleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _ArticleService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ArticleService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ArticleService_Delete_Handler,
+		},
+		{
+			MethodName: "Detail",
+			Handler:    _ArticleService_Detail_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ArticleService_List_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _ArticleService_Search_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _ArticleService_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchCreate",
+			Handler:    _ArticleService_BatchCreate_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _ArticleService_BatchDelete_Handler,
+		},
+		{
+			MethodName: "ListAudit",
+			Handler:    _ArticleService_ListAudit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Import",
+			Handler:       _ArticleService_Import_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _ArticleService_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _ArticleService_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "article/v1/article.proto",
+}
@@ -0,0 +1,10 @@
+// Package openapiv2 embeds the OpenAPI documents generated from the proto
+// files by protoc-gen-openapiv2.
+package openapiv2
+
+import _ "embed"
+
+// Article documents the REST routes of article.v1.ArticleService.
+//
+//go:embed article/v1/article.swagger.json
+var Article []byte
@@ -0,0 +1,146 @@
+// Package articleio reads and writes articles in the file formats understood
+// by the import and export commands. Field names follow the json tags of
+// entity.Article.
+package articleio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	// FormatParquet is only supported for writing.
+	FormatParquet = "parquet"
+)
+
+// TagSeparator separates the tags of an article within a single CSV cell.
+const TagSeparator = ";"
+
+// Reader yields one article per call and io.EOF at the end of the input.
+// Malformed records are returned as errors wrapping article.ErrValidation so
+// that callers may skip them and continue.
+type Reader interface {
+	Next() (*entity.Article, error)
+}
+
+// FormatFromPath guesses the format from the file extension.
+func FormatFromPath(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".jsonl", ".ndjson", ".json":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".parquet":
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("cannot guess format of %q, use --format", path)
+	}
+}
+
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case FormatJSONL:
+		return NewJSONLReader(r), nil
+	case FormatCSV:
+		return NewCSVReader(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type JSONLReader struct {
+	scanner *bufio.Scanner
+}
+
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	return &JSONLReader{scanner: scanner}
+}
+
+func (r *JSONLReader) Next() (*entity.Article, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	a := &entity.Article{}
+	if err := json.Unmarshal(r.scanner.Bytes(), a); err != nil {
+		return nil, fmt.Errorf("%w: %w", article.ErrValidation, err)
+	}
+	return a, nil
+}
+
+// CSVReader reads a CSV file whose first line names the columns. Unknown
+// columns are ignored; title and slug are required.
+type CSVReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv: missing header")
+	} else if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"title", "slug"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv: missing %q column", required)
+		}
+	}
+	return &CSVReader{reader: reader, columns: columns}, nil
+}
+
+func (r *CSVReader) Next() (*entity.Article, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	} else if errors.Is(err, csv.ErrQuote) || errors.Is(err, csv.ErrBareQuote) || errors.Is(err, csv.ErrFieldCount) {
+		return nil, fmt.Errorf("%w: %w", article.ErrValidation, err)
+	} else if err != nil {
+		return nil, err
+	}
+	field := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	a := &entity.Article{Title: field("title"), Slug: field("slug"), Status: field("status")}
+	if tags := field("tags"); tags != "" {
+		a.Tags = strings.Split(tags, TagSeparator)
+	}
+	if id := field("ID"); id != "" {
+		if a.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: ID: %w", article.ErrValidation, err)
+		}
+	}
+	if createdAt := field("createdAt"); createdAt != "" {
+		if a.CreatedAt, err = strconv.ParseUint(createdAt, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: createdAt: %w", article.ErrValidation, err)
+		}
+	}
+	return a, nil
+}
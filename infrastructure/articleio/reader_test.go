@@ -0,0 +1,82 @@
+package articleio
+
+import (
+	"errors"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, r Reader) ([]*entity.Article, []error) {
+	t.Helper()
+	var (
+		articles []*entity.Article
+		errs     []error
+	)
+	for {
+		a, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return articles, errs
+		} else if errors.Is(err, article.ErrValidation) {
+			errs = append(errs, err)
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		articles = append(articles, a)
+	}
+}
+
+func TestJSONLReader(t *testing.T) {
+	input := `{"title":"Go","slug":"go","tags":["lang"],"createdAt":10}
+not json
+{"ID":7,"title":"Rust","slug":"rust"}
+`
+	articles, errs := readAll(t, NewJSONLReader(strings.NewReader(input)))
+	want := []*entity.Article{
+		{Title: "Go", Slug: "go", Tags: []string{"lang"}, CreatedAt: 10},
+		{ID: 7, Title: "Rust", Slug: "rust"},
+	}
+	if !reflect.DeepEqual(articles, want) {
+		t.Errorf("articles = %+v, want %+v", articles, want)
+	}
+	if len(errs) != 1 {
+		t.Errorf("got %d errors, want 1", len(errs))
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := "slug,title,tags,extra\ngo,Go,lang;backend,x\nrust,Rust,,\n"
+	r, err := NewCSVReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	articles, errs := readAll(t, r)
+	want := []*entity.Article{
+		{Title: "Go", Slug: "go", Tags: []string{"lang", "backend"}},
+		{Title: "Rust", Slug: "rust"},
+	}
+	if !reflect.DeepEqual(articles, want) || len(errs) != 0 {
+		t.Errorf("articles = %+v, errors = %v", articles, errs)
+	}
+}
+
+func TestCSVReader_BadRows(t *testing.T) {
+	r, err := NewCSVReader(strings.NewReader("title,slug,ID\nGo,go,abc\nRust,rust,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	articles, errs := readAll(t, r)
+	if len(articles) != 1 || articles[0].ID != 2 || len(errs) != 1 {
+		t.Errorf("articles = %+v, errors = %v", articles, errs)
+	}
+}
+
+func TestCSVReader_MissingColumn(t *testing.T) {
+	if _, err := NewCSVReader(strings.NewReader("title,tags\n")); err == nil {
+		t.Error("expected an error for the missing slug column")
+	}
+}
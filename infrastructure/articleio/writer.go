@@ -0,0 +1,129 @@
+package articleio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/parquet-go/parquet-go"
+	"io"
+	"m1-article-service/domain/entity"
+	"strconv"
+	"strings"
+)
+
+// Writer encodes articles one at a time. Close flushes buffered output but
+// does not close the underlying io.Writer.
+type Writer interface {
+	Write(*entity.Article) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatParquet:
+		return NewParquetWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type JSONLWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	buf := bufio.NewWriter(w)
+	return &JSONLWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (w *JSONLWriter) Write(a *entity.Article) error {
+	return w.enc.Encode(a)
+}
+
+func (w *JSONLWriter) Close() error {
+	return w.buf.Flush()
+}
+
+// csvHeader uses the json tags of entity.Article so that exports can be
+// imported again by CSVReader.
+var csvHeader = []string{"ID", "title", "slug", "tags", "createdAt", "status"}
+
+type CSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) Write(a *entity.Article) error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	return w.w.Write([]string{
+		strconv.FormatInt(a.ID, 10),
+		a.Title,
+		a.Slug,
+		strings.Join(a.Tags, TagSeparator),
+		strconv.FormatUint(a.CreatedAt, 10),
+		a.Status,
+	})
+}
+
+// Close writes the header even when no article was written.
+func (w *CSVWriter) Close() error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// parquetArticle is the Parquet schema of an exported article.
+type parquetArticle struct {
+	ID        int64    `parquet:"ID"`
+	Title     string   `parquet:"title"`
+	Slug      string   `parquet:"slug"`
+	Tags      []string `parquet:"tags,list"`
+	CreatedAt uint64   `parquet:"createdAt"`
+	Status    string   `parquet:"status"`
+}
+
+// ParquetWriter buffers rows into row groups and writes the footer on Close,
+// so the output is only readable once Close has returned.
+type ParquetWriter struct {
+	w *parquet.GenericWriter[parquetArticle]
+}
+
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{w: parquet.NewGenericWriter[parquetArticle](w)}
+}
+
+func (w *ParquetWriter) Write(a *entity.Article) error {
+	_, err := w.w.Write([]parquetArticle{{
+		ID:        a.ID,
+		Title:     a.Title,
+		Slug:      a.Slug,
+		Tags:      a.Tags,
+		CreatedAt: a.CreatedAt,
+		Status:    a.Status,
+	}})
+	return err
+}
+
+func (w *ParquetWriter) Close() error {
+	return w.w.Close()
+}
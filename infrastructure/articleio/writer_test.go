@@ -0,0 +1,83 @@
+package articleio
+
+import (
+	"bytes"
+	"github.com/parquet-go/parquet-go"
+	"m1-article-service/domain/entity"
+	"reflect"
+	"testing"
+)
+
+var exported = []*entity.Article{
+	{ID: 1, Title: "Go", Slug: "go", Tags: []string{"lang", "backend"}, CreatedAt: 10, Status: entity.StatusPublished},
+	{ID: 2, Title: "Rust, again", Slug: "rust", Tags: []string{}, CreatedAt: 20, Status: entity.StatusDraft},
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSONL, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w, err := NewWriter(format, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range exported {
+				if err := w.Write(a); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := NewReader(format, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			articles, errs := readAll(t, r)
+			if len(errs) != 0 || len(articles) != len(exported) {
+				t.Fatalf("articles = %+v, errors = %v", articles, errs)
+			}
+			for i, a := range articles {
+				want := *exported[i]
+				if len(want.Tags) == 0 && len(a.Tags) == 0 {
+					want.Tags = a.Tags
+				}
+				if !reflect.DeepEqual(*a, want) {
+					t.Errorf("article %d = %+v, want %+v", i, *a, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCSVWriter_EmptyHasHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewCSVWriter(buf).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "ID,title,slug,tags,createdAt,status\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewParquetWriter(buf)
+	for _, a := range exported {
+		if err := w.Write(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.Read[parquetArticle](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Slug != "go" || !reflect.DeepEqual(rows[0].Tags, []string{"lang", "backend"}) || rows[1].Status != entity.StatusDraft {
+		t.Errorf("rows = %+v", rows)
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU_Evicts(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if v, ok, _ := c.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("a = %q, %v", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("len = %d, want 2", c.Len())
+	}
+}
+
+func TestLRU_Expires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "a"); !ok {
+		t.Fatal("entry expired early")
+	}
+	now = now.Add(time.Minute)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("entry did not expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expired entry kept, len = %d", c.Len())
+	}
+}
+
+func TestLRU_IncrAndDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+	for want := int64(1); want <= 3; want++ {
+		if n, _ := c.Incr(ctx, "n"); n != want {
+			t.Fatalf("incr = %d, want %d", n, want)
+		}
+	}
+	c.Delete(ctx, "n", "missing")
+	if n, _ := c.Incr(ctx, "n"); n != 1 {
+		t.Errorf("incr after delete = %d, want 1", n)
+	}
+}
@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis implements the subset of the Redis protocol used by Redis.
+type fakeRedis struct {
+	mu       sync.Mutex
+	data     map[string]string
+	expires  map[string]time.Time
+	password string
+}
+
+func startFakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	srv := &fakeRedis{data: map[string]string{}, expires: map[string]time.Time{}, password: password}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+		items := reply.([]any)
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i] = string(item.([]byte))
+		}
+		if !authed && strings.ToUpper(args[0]) != "AUTH" {
+			fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+			continue
+		}
+		if strings.ToUpper(args[0]) == "AUTH" {
+			authed = args[1] == s.password
+		}
+		fmt.Fprint(conn, s.handle(args))
+	}
+}
+
+func (s *fakeRedis) handle(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "PING", "SELECT":
+		return "+PONG\r\n"
+	case "AUTH":
+		if args[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		return "+OK\r\n"
+	case "GET":
+		v, ok := s.data[args[1]]
+		if exp, has := s.expires[args[1]]; ok && has && !time.Now().Before(exp) {
+			ok = false
+		}
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case "SET":
+		s.data[args[1]] = args[2]
+		delete(s.expires, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			s.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "DEL":
+		n := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				delete(s.data, key)
+				n++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", n)
+	case "INCR":
+		var n int64
+		if v, ok := s.data[args[1]]; ok {
+			var err error
+			if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return "-ERR value is not an integer\r\n"
+			}
+		}
+		n++
+		s.data[args[1]] = strconv.FormatInt(n, 10)
+		return fmt.Sprintf(":%d\r\n", n)
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	c := NewRedis(RedisConfig{Addr: startFakeRedis(t, "secret"), Password: "secret", PoolSize: 1})
+	defer c.Close()
+
+	if err := c.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := c.Get(ctx, "a"); ok || err != nil {
+		t.Fatalf("get missing = %v, %v", ok, err)
+	}
+	if err := c.Set(ctx, "a", []byte("hello\r\nworld"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := c.Get(ctx, "a"); !ok || err != nil || string(v) != "hello\r\nworld" {
+		t.Fatalf("get = %q, %v, %v", v, ok, err)
+	}
+	if n, err := c.Incr(ctx, "n"); n != 1 || err != nil {
+		t.Fatalf("incr = %d, %v", n, err)
+	}
+	if err := c.Delete(ctx, "a", "n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("deleted key still present")
+	}
+}
+
+func TestRedis_Expiry(t *testing.T) {
+	ctx := context.Background()
+	c := NewRedis(RedisConfig{Addr: startFakeRedis(t, "")})
+	defer c.Close()
+	if err := c.Set(ctx, "a", []byte("1"), 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Error("key did not expire")
+	}
+}
+
+func TestRedis_Timeout(t *testing.T) {
+	// a server that accepts connections but never replies
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+
+	c := NewRedis(RedisConfig{Addr: lis.Addr().String(), Timeout: 20 * time.Millisecond})
+	defer c.Close()
+	done := make(chan error, 1)
+	go func() { done <- c.Ping(context.Background()) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("error = %v, want a deadline exceeded error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("command without a context deadline did not time out")
+	}
+}
+
+func TestRedis_ErrorReply(t *testing.T) {
+	ctx := context.Background()
+	c := NewRedis(RedisConfig{Addr: startFakeRedis(t, "")})
+	defer c.Close()
+	c.Set(ctx, "a", []byte("text"), 0)
+
+	_, err := c.Incr(ctx, "a")
+	var redisErr RedisError
+	if !errors.As(err, &redisErr) {
+		t.Fatalf("error = %v, want a RedisError", err)
+	}
+	// The connection is still usable after an error reply.
+	if err := c.Ping(ctx); err != nil {
+		t.Error(err)
+	}
+}
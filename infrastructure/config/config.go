@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"m1-article-service/infrastructure/ratelimit"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config is the complete configuration of the service. Every field is read,
+// in increasing order of precedence, from its default, the YAML file, the
+// .env file, the environment and finally command-line flags.
+//
+// Struct tags:
+//   - env: environment variable (and .env key); KEY_FILE reads the value from a file
+//   - yaml: key in the YAML file; the flag name is derived from it (server_addr -> --server-addr)
+//   - default: value used when no source sets the field
+//   - validate: comma separated rules, see validateField
+type Config struct {
+	ServerAddr          string        `env:"ServerAddr" yaml:"server_addr" default:"localhost:8000" validate:"required,hostport"`
+	DatabaseHost        string        `env:"DATABASE_HOST" yaml:"database_host" validate:"required,url"`
+	DatabasePassword    string        `env:"DATABASE_PASSWORD" yaml:"database_password"`
+	ShutdownTimeout     time.Duration `env:"SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout" default:"15s" validate:"min=0"`
+	HealthCheckInterval time.Duration `env:"HEALTH_CHECK_INTERVAL" yaml:"health_check_interval" default:"10s" validate:"min=1"`
+
+	DatabaseMaxConns          int           `env:"DATABASE_MAX_CONNS" yaml:"database_max_conns" default:"10" validate:"min=1"`
+	DatabaseMinConns          int           `env:"DATABASE_MIN_CONNS" yaml:"database_min_conns" default:"0" validate:"min=0"`
+	DatabaseMaxConnLifetime   time.Duration `env:"DATABASE_MAX_CONN_LIFETIME" yaml:"database_max_conn_lifetime" default:"1h" validate:"min=1"`
+	DatabaseMaxConnIdleTime   time.Duration `env:"DATABASE_MAX_CONN_IDLE_TIME" yaml:"database_max_conn_idle_time" default:"30m" validate:"min=1"`
+	DatabaseHealthCheckPeriod time.Duration `env:"DATABASE_HEALTH_CHECK_PERIOD" yaml:"database_health_check_period" default:"1m" validate:"min=1"`
+	DatabaseStatementTimeout  time.Duration `env:"DATABASE_STATEMENT_TIMEOUT" yaml:"database_statement_timeout" default:"30s" validate:"min=0"`
+	DatabaseConnectTimeout    time.Duration `env:"DATABASE_CONNECT_TIMEOUT" yaml:"database_connect_timeout" default:"1m" validate:"min=1"`
+	DatabaseReadRetries       int           `env:"DATABASE_READ_RETRIES" yaml:"database_read_retries" default:"2" validate:"min=0"`
+
+	MigrateOnStartup   bool          `env:"MIGRATE_ON_STARTUP" yaml:"migrate_on_startup" default:"false"`
+	MigrateLockTimeout time.Duration `env:"MIGRATE_LOCK_TIMEOUT" yaml:"migrate_lock_timeout" default:"1m" validate:"min=1"`
+
+	LogLevel          string `env:"LOG_LEVEL" yaml:"log_level" default:"info" validate:"oneof=debug info warn error"`
+	LogFormat         string `env:"LOG_FORMAT" yaml:"log_format" default:"json" validate:"oneof=json console"`
+	LogServiceAddr    string `env:"LOG_SERVICE_ADDR" yaml:"log_service_addr" validate:"hostport"`
+	LogShipBufferSize int    `env:"LOG_SHIP_BUFFER_SIZE" yaml:"log_ship_buffer_size" default:"10000" validate:"min=1"`
+	LogShipPolicy     string `env:"LOG_SHIP_POLICY" yaml:"log_ship_policy" default:"drop" validate:"oneof=drop block"`
+
+	MetricsAddr string `env:"METRICS_ADDR" yaml:"metrics_addr" validate:"hostport"`
+	GatewayAddr string `env:"GATEWAY_ADDR" yaml:"gateway_addr" validate:"hostport"`
+
+	// Admins is the comma separated list of the principals allowed to call
+	// the admin methods, ArticleService.ListAudit and WebhookService;
+	// nobody is when it is empty.
+	Admins string `env:"ADMINS" yaml:"admins"`
+	// TrustedProxySecret is the secret the API gateway and other trusted
+	// proxies send in x-proxy-secret; x-principal and x-forwarded-for are
+	// ignored on requests without it.
+	TrustedProxySecret string `env:"TRUSTED_PROXY_SECRET" yaml:"trusted_proxy_secret"`
+
+	CacheBackend  string        `env:"CACHE_BACKEND" yaml:"cache_backend" default:"none" validate:"oneof=none memory redis"`
+	CacheSize     int           `env:"CACHE_SIZE" yaml:"cache_size" default:"10000" validate:"min=1"`
+	CacheTTL      time.Duration `env:"CACHE_TTL" yaml:"cache_ttl" default:"1m" validate:"min=1"`
+	RedisAddr     string        `env:"REDIS_ADDR" yaml:"redis_addr" validate:"hostport"`
+	RedisPassword string        `env:"REDIS_PASSWORD" yaml:"redis_password"`
+	RedisDB       int           `env:"REDIS_DB" yaml:"redis_db" default:"0" validate:"min=0"`
+	RedisTimeout  time.Duration `env:"REDIS_TIMEOUT" yaml:"redis_timeout" default:"1s" validate:"min=1ms"`
+
+	OutboxSink         string        `env:"OUTBOX_SINK" yaml:"outbox_sink" default:"discard" validate:"oneof=discard log stdout"`
+	OutboxBatchSize    int           `env:"OUTBOX_BATCH_SIZE" yaml:"outbox_batch_size" default:"100" validate:"min=1"`
+	OutboxPollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" yaml:"outbox_poll_interval" default:"1s" validate:"min=1"`
+
+	WatchBufferSize int           `env:"WATCH_BUFFER_SIZE" yaml:"watch_buffer_size" default:"256" validate:"min=1"`
+	WatchRetention  time.Duration `env:"WATCH_RETENTION" yaml:"watch_retention" default:"24h" validate:"min=0"`
+
+	WebhookConcurrency  int           `env:"WEBHOOK_CONCURRENCY" yaml:"webhook_concurrency" default:"4" validate:"min=1"`
+	WebhookTimeout      time.Duration `env:"WEBHOOK_TIMEOUT" yaml:"webhook_timeout" default:"10s" validate:"min=1"`
+	WebhookMaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" yaml:"webhook_max_attempts" default:"8" validate:"min=1"`
+	WebhookBackoff      time.Duration `env:"WEBHOOK_BACKOFF" yaml:"webhook_backoff" default:"30s" validate:"min=1"`
+	WebhookMaxBackoff   time.Duration `env:"WEBHOOK_MAX_BACKOFF" yaml:"webhook_max_backoff" default:"1h" validate:"min=1"`
+	WebhookAllowPrivate bool          `env:"WEBHOOK_ALLOW_PRIVATE" yaml:"webhook_allow_private" default:"false"`
+
+	RateLimitBackend string `env:"RATE_LIMIT_BACKEND" yaml:"rate_limit_backend" default:"none" validate:"oneof=none memory postgres"`
+	RateLimitRules   string `env:"RATE_LIMIT_RULES" yaml:"rate_limit_rules" default:"*=100/1s"`
+
+	IdempotencyTTL time.Duration `env:"IDEMPOTENCY_TTL" yaml:"idempotency_ttl" default:"24h" validate:"min=1s"`
+	// IdempotencyLease is how long a key stays reserved while its request
+	// runs; it must outlast the slowest request or a retry runs it again.
+	IdempotencyLease time.Duration `env:"IDEMPOTENCY_LEASE" yaml:"idempotency_lease" default:"1m" validate:"min=1s"`
+
+	TracingExporter    string  `env:"TRACING_EXPORTER" yaml:"tracing_exporter" default:"none" validate:"oneof=none stdout file otlp"`
+	TracingFile        string  `env:"TRACING_FILE" yaml:"tracing_file" default:"traces.json"`
+	OTLPEndpoint       string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT" yaml:"otlp_endpoint" validate:"hostport"`
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" yaml:"tracing_sample_ratio" default:"1" validate:"min=0,max=1"`
+}
+
+// Storage drivers selected by the scheme of DatabaseHost.
+const (
+	DriverPostgres = "postgres"
+	// DriverSQLite stores articles in a local file (sqlite:///path/to/file.db).
+	DriverSQLite = "sqlite"
+	// DriverMemory keeps articles in process memory (memory://); nothing
+	// survives a restart.
+	DriverMemory = "memory"
+)
+
+// DatabaseDriver returns the storage driver named by the scheme of
+// DatabaseHost, or an empty string when the scheme is not supported.
+func (c *Config) DatabaseDriver() string {
+	u, err := url.Parse(c.DatabaseHost)
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "postgres", "postgresql":
+		return DriverPostgres
+	case "sqlite":
+		return DriverSQLite
+	case "memory":
+		return DriverMemory
+	default:
+		return ""
+	}
+}
+
+// DatabaseURL returns DatabaseHost with its password replaced by
+// DatabasePassword when the latter is set.
+func (c *Config) DatabaseURL() string {
+	if c.DatabasePassword == "" {
+		return c.DatabaseHost
+	}
+	u, err := url.Parse(c.DatabaseHost)
+	if err != nil || u.User == nil {
+		return c.DatabaseHost
+	}
+	u.User = url.UserPassword(u.User.Username(), c.DatabasePassword)
+	return u.String()
+}
+
+// AdminList returns the principals listed in Admins.
+func (c *Config) AdminList() []string {
+	var admins []string
+	for _, p := range strings.Split(c.Admins, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			admins = append(admins, p)
+		}
+	}
+	return admins
+}
+
+// validate checks rules spanning several fields.
+func (c *Config) validate() error {
+	var errs []error
+	if c.TracingExporter == "file" && c.TracingFile == "" {
+		errs = append(errs, errors.New("TRACING_FILE: required when TRACING_EXPORTER is file"))
+	}
+	if c.TracingExporter == "otlp" && c.OTLPEndpoint == "" {
+		errs = append(errs, errors.New("OTEL_EXPORTER_OTLP_ENDPOINT: required when TRACING_EXPORTER is otlp"))
+	}
+	if c.DatabaseHost != "" && c.DatabaseDriver() == "" {
+		errs = append(errs, errors.New("DATABASE_HOST: unsupported scheme, use postgres://, sqlite:// or memory://"))
+	}
+	if c.CacheBackend == "redis" && c.RedisAddr == "" {
+		errs = append(errs, errors.New("REDIS_ADDR: required when CACHE_BACKEND is redis"))
+	}
+	if c.DatabaseMinConns > c.DatabaseMaxConns {
+		errs = append(errs, errors.New("DATABASE_MIN_CONNS: must not exceed DATABASE_MAX_CONNS"))
+	}
+	if c.WebhookMaxBackoff < c.WebhookBackoff {
+		errs = append(errs, errors.New("WEBHOOK_MAX_BACKOFF: must not be below WEBHOOK_BACKOFF"))
+	}
+	if _, err := ratelimit.ParseRules(c.RateLimitRules); err != nil {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_RULES: %w", err))
+	}
+	if c.RateLimitBackend == "postgres" && c.DatabaseHost != "" && c.DatabaseDriver() != DriverPostgres {
+		errs = append(errs, errors.New("RATE_LIMIT_BACKEND: postgres requires a postgres:// DATABASE_HOST"))
+	}
+	if c.DatabasePassword != "" {
+		if u, err := url.Parse(c.DatabaseHost); err == nil && u.User == nil {
+			errs = append(errs, fmt.Errorf("DATABASE_PASSWORD: DATABASE_HOST has no user to apply it to"))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultEnvFile = ".env"
+	fileSuffix     = "_FILE"
+)
+
+// Load builds the configuration from all sources. args are command-line
+// arguments without the program name; besides one flag per field they
+// accept --config (YAML file) and --env-file (defaults to .env, which is
+// optional). The arguments left after the flags are returned.
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return LoadFlags(fs, args)
+}
+
+// LoadFlags is like Load but registers the configuration flags on fs so
+// that callers can add their own. Fields whose environment key is listed in
+// optional are not required even when tagged so; commands that never touch
+// the database pass "DATABASE_HOST".
+func LoadFlags(fs *flag.FlagSet, args []string, optional ...string) (*Config, []string, error) {
+	cfg := &Config{}
+	fields := fieldsOf(cfg)
+	for i := range fields {
+		if contains(optional, fields[i].env) {
+			fields[i].optional = true
+		}
+	}
+
+	yamlFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	envFile := fs.String("env-file", defaultEnvFile, "path to a .env file")
+	flagValues := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		flagValues[f.flag] = fs.String(f.flag, "", fmt.Sprintf("%s (env %s, default %q)", f.yaml, f.env, f.def))
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	setFlags := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if f.def != "" {
+			values[f.env] = f.def
+		}
+	}
+
+	if *yamlFile != "" {
+		if err := readYAML(*yamlFile, fields, values); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	dotenv, err := godotenv.Read(*envFile)
+	if err != nil && !(errors.Is(err, os.ErrNotExist) && !setFlags["env-file"]) {
+		return nil, nil, fmt.Errorf("reading %s: %w", *envFile, err)
+	}
+
+	var errs []error
+	for _, f := range fields {
+		for _, lookup := range []func(string) (string, bool){
+			func(k string) (string, bool) { v, ok := dotenv[k]; return v, ok },
+			os.LookupEnv,
+		} {
+			if v, ok := lookup(f.env); ok {
+				values[f.env] = v
+			}
+			if path, ok := lookup(f.env + fileSuffix); ok {
+				secret, err := os.ReadFile(path)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s%s: %w", f.env, fileSuffix, err))
+					continue
+				}
+				values[f.env] = strings.TrimRight(string(secret), "\r\n")
+			}
+		}
+		if setFlags[f.flag] {
+			values[f.env] = *flagValues[f.flag]
+		}
+	}
+
+	for _, f := range fields {
+		raw, ok := values[f.env]
+		if ok {
+			if err := f.set(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.env, err))
+				continue
+			}
+		}
+		if err := f.validate(raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.env, err))
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, cfg.validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return cfg, fs.Args(), nil
+}
+
+func readYAML(path string, fields []field, values map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	known := make(map[string]field, len(fields))
+	for _, f := range fields {
+		known[f.yaml] = f
+	}
+	var errs []error
+	for key, v := range doc {
+		f, ok := known[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key %q", path, key))
+			continue
+		}
+		values[f.env] = fmt.Sprint(v)
+	}
+	return errors.Join(errs...)
+}
+
+type field struct {
+	value reflect.Value
+	env   string
+	yaml  string
+	flag  string
+	def   string
+	rules []string
+	// optional disables the required rule.
+	optional bool
+}
+
+func fieldsOf(cfg *Config) []field {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		f := field{
+			value: v.Field(i),
+			env:   sf.Tag.Get("env"),
+			yaml:  sf.Tag.Get("yaml"),
+			def:   sf.Tag.Get("default"),
+		}
+		f.flag = strings.ReplaceAll(f.yaml, "_", "-")
+		if rules := sf.Tag.Get("validate"); rules != "" {
+			f.rules = strings.Split(rules, ",")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func (f field) set(raw string) error {
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(raw)
+	case int:
+		i, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		f.value.SetInt(int64(i))
+	case float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		f.value.SetFloat(n)
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		f.value.SetBool(b)
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", raw)
+		}
+		f.value.SetInt(int64(d))
+	default:
+		return fmt.Errorf("unsupported type %s", f.value.Type())
+	}
+	return nil
+}
+
+// validateField rules:
+//   - required: the value must not be empty
+//   - oneof=a b c: the value must be one of the listed words
+//   - url: the value must be an absolute URL
+//   - hostport: the value must be a host:port pair
+//   - min=n / max=n: numeric bounds; durations take a duration such as 1s
+//
+// Rules other than required are skipped for empty values.
+func (f field) validate(raw string) error {
+	for _, rule := range f.rules {
+		name, arg, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			if raw == "" && !f.optional {
+				return errors.New("is required")
+			}
+			continue
+		}
+		if raw == "" {
+			continue
+		}
+		switch name {
+		case "oneof":
+			if !contains(strings.Fields(arg), raw) {
+				return fmt.Errorf("%q must be one of %s", raw, strings.Join(strings.Fields(arg), ", "))
+			}
+		case "url":
+			if u, err := url.Parse(raw); err != nil || u.Scheme == "" {
+				return fmt.Errorf("%q is not a valid URL", raw)
+			}
+		case "hostport":
+			if _, _, err := net.SplitHostPort(raw); err != nil {
+				return fmt.Errorf("%q is not a valid host:port", raw)
+			}
+		case "min", "max":
+			bound, err := f.bound(arg)
+			if err != nil {
+				return fmt.Errorf("invalid rule %q", rule)
+			}
+			n := f.number()
+			if (name == "min" && n < bound) || (name == "max" && n > bound) {
+				return fmt.Errorf("%s must be %s %s", raw, map[string]string{"min": ">=", "max": "<="}[name], arg)
+			}
+		default:
+			return fmt.Errorf("unknown validation rule %q", name)
+		}
+	}
+	return nil
+}
+
+// bound parses the argument of a min or max rule. Bounds of durations are
+// durations, or nanoseconds when given without a unit.
+func (f field) bound(arg string) (float64, error) {
+	if _, ok := f.value.Interface().(time.Duration); ok {
+		if d, err := time.ParseDuration(arg); err == nil {
+			return float64(d), nil
+		}
+	}
+	return strconv.ParseFloat(arg, 64)
+}
+
+func (f field) number() float64 {
+	switch f.value.Kind() {
+	case reflect.Int, reflect.Int64:
+		return float64(f.value.Int())
+	case reflect.Float64:
+		return f.value.Float()
+	default:
+		return 0
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
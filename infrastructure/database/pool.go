@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/infrastructure/config"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/tracing"
+	"strconv"
+	"time"
+)
+
+// NewPool creates a connection pool tuned by cfg and waits for the database
+// to become reachable, retrying with exponential backoff for up to
+// cfg.DatabaseConnectTimeout.
+func NewPool(ctx context.Context, cfg *config.Config, logger logger.Logger) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL())
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.MaxConns = int32(cfg.DatabaseMaxConns)
+	poolConfig.MinConns = int32(cfg.DatabaseMinConns)
+	poolConfig.MaxConnLifetime = cfg.DatabaseMaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.DatabaseMaxConnIdleTime
+	poolConfig.HealthCheckPeriod = cfg.DatabaseHealthCheckPeriod
+	if cfg.DatabaseStatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DatabaseStatementTimeout.Milliseconds(), 10)
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.DatabaseConnectTimeout)
+	defer cancel()
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err = pool.Ping(ctx)
+		if err == nil {
+			return pool, nil
+		}
+		logger.Warning(ctx, "database is not reachable yet", "attempt", attempt, "retry_in", backoff, "error", err.Error())
+		if !sleep(ctx, backoff) {
+			pool.Close()
+			return nil, fmt.Errorf("connecting to database: %w", err)
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// sleep waits for d and reports false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
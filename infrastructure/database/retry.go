@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// IsTransient reports whether err is caused by a lost or refused connection
+// rather than by the query itself, so that repeating an idempotent query may
+// succeed.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// class 08: connection exception, 57P01-57P03: server shutting down
+		// or not accepting connections yet
+		return strings.HasPrefix(pgErr.Code, "08") ||
+			pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// RetryRead runs the idempotent read fn up to retries+1 times, backing off
+// exponentially between attempts while its error is transient.
+func RetryRead(ctx context.Context, retries int, fn func(ctx context.Context) error) error {
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= retries || !IsTransient(err) || !sleep(ctx, backoff) {
+			return err
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
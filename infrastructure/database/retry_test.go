@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"syscall"
+	"testing"
+)
+
+func TestIsTransient(t *testing.T) {
+	var tests = []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "other", err: errors.New("boom"), want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsTransient(test.err); got != test.want {
+				t.Errorf("IsTransient(%v) = %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryRead(t *testing.T) {
+	calls := 0
+	err := RetryRead(context.Background(), 2, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return syscall.ECONNRESET
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("err = %v, calls = %d; want success after 3 calls", err, calls)
+	}
+
+	calls = 0
+	permanent := &pgconn.PgError{Code: "42P01"}
+	if err := RetryRead(context.Background(), 2, func(ctx context.Context) error {
+		calls++
+		return permanent
+	}); !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("err = %v, calls = %d; non transient errors must not be retried", err, calls)
+	}
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"m1-article-service/infrastructure/config"
+	_ "modernc.org/sqlite"
+	"net/url"
+	"strings"
+)
+
+// sqlitePragmas enable foreign keys for the tag cascade, let readers work
+// alongside the single writer and make writers wait for the lock instead of
+// failing. Write transactions take the lock up front so two of them cannot
+// deadlock upgrading from a read lock.
+const sqlitePragmas = "_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate"
+
+// SQLitePath returns the database file of a sqlite:// URL, e.g.
+// sqlite:///var/lib/articles.db or sqlite://articles.db for a relative path.
+func SQLitePath(databaseURL string) string {
+	path := strings.TrimPrefix(databaseURL, "sqlite://")
+	path, _, _ = strings.Cut(path, "?")
+	return path
+}
+
+// OpenSQLite opens the database file named by cfg.DatabaseHost, creating it
+// if needed.
+func OpenSQLite(ctx context.Context, cfg *config.Config) (*sql.DB, error) {
+	dsn := SQLitePath(cfg.DatabaseHost) + "?" + sqlitePragmas
+	if u, err := url.Parse(cfg.DatabaseHost); err == nil && u.RawQuery != "" {
+		dsn += "&" + u.RawQuery
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.DatabaseMaxConns)
+	db.SetConnMaxLifetime(cfg.DatabaseMaxConnLifetime)
+	db.SetConnMaxIdleTime(cfg.DatabaseMaxConnIdleTime)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,150 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	logger "m1-article-service/infrastructure/log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Hook is called once while the application is shutting down.
+type Hook func(ctx context.Context) error
+
+// Worker is a long-running background job; ctx is cancelled when the
+// application starts draining.
+type Worker func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+// Manager coordinates the shutdown of every component of the service.
+// Servers are stopped first, then background workers are drained and
+// finally resources such as connection pools are released.
+type Manager struct {
+	logger logger.Logger
+
+	mu      sync.Mutex
+	stops   []namedHook
+	closers []namedHook
+
+	workers      sync.WaitGroup
+	failed       map[string]error
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
+	shutdownOnce sync.Once
+	shutdownErr  error
+}
+
+func NewManager(logger logger.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		logger:       logger,
+		failed:       make(map[string]error),
+		workerCtx:    ctx,
+		cancelWorker: cancel,
+	}
+}
+
+// OnStop registers a hook that stops accepting new work (e.g. a server).
+// Stop hooks run in reverse registration order before workers are drained.
+func (m *Manager) OnStop(name string, hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stops = append(m.stops, namedHook{name: name, hook: hook})
+}
+
+// OnClose registers a hook that releases a resource (e.g. a database pool).
+// Close hooks run in reverse registration order after workers are drained.
+func (m *Manager) OnClose(name string, hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closers = append(m.closers, namedHook{name: name, hook: hook})
+}
+
+// Go starts a background worker that is drained on shutdown.
+func (m *Manager) Go(name string, worker Worker) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		err := worker(m.workerCtx)
+		if m.workerCtx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("exited unexpectedly")
+		}
+		err = fmt.Errorf("worker %s: %w", name, err)
+		m.logger.Error(m.workerCtx, err)
+		m.mu.Lock()
+		m.failed[name] = err
+		m.mu.Unlock()
+	}()
+}
+
+// CheckWorkers reports an error if any background worker has stopped
+// before shutdown was requested.
+func (m *Manager) CheckWorkers(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := make([]error, 0, len(m.failed))
+	for _, err := range m.failed {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Wait blocks until the process receives SIGINT/SIGTERM or ctx is done.
+func (m *Manager) Wait(ctx context.Context) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+}
+
+// Shutdown runs stop hooks, drains workers and runs close hooks. Every
+// phase is bounded by ctx; errors from all hooks are joined. Subsequent
+// calls return the result of the first one.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.shutdownOnce.Do(func() {
+		m.mu.Lock()
+		stops := append([]namedHook(nil), m.stops...)
+		closers := append([]namedHook(nil), m.closers...)
+		m.mu.Unlock()
+
+		var errs []error
+		errs = append(errs, m.run(ctx, stops)...)
+
+		m.cancelWorker()
+		drained := make(chan struct{})
+		go func() {
+			m.workers.Wait()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("draining workers: %w", ctx.Err()))
+		}
+
+		errs = append(errs, m.run(ctx, closers)...)
+		m.shutdownErr = errors.Join(errs...)
+	})
+	return m.shutdownErr
+}
+
+func (m *Manager) run(ctx context.Context, hooks []namedHook) []error {
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		m.logger.Info(ctx, "shutting down", "component", h.name)
+		if err := h.hook(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	return errs
+}
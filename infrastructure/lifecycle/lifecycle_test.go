@@ -0,0 +1,63 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	infraMock "m1-article-service/mock/infrastructure"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestManager_ShutdownOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	var order []string
+	record := func(name string) Hook {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	m := NewManager(loggerMock)
+	m.OnClose("pool", record("pool"))
+	m.OnClose("cache", record("cache"))
+	m.OnStop("grpc", record("grpc"))
+	m.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		order = append(order, "worker")
+		return ctx.Err()
+	})
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"grpc", "worker", "cache", "pool"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("shutdown order = %v, want %v", order, want)
+	}
+}
+
+func TestManager_ShutdownTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	m := NewManager(loggerMock)
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	m.Go("stuck", func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
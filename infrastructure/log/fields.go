@@ -0,0 +1,14 @@
+package logger
+
+import (
+	"m1-article-service/infrastructure/principal"
+	"m1-article-service/infrastructure/requestid"
+	"m1-article-service/infrastructure/traceid"
+)
+
+// DefaultContextFields are the request scoped values every logger attaches.
+var DefaultContextFields = []ContextField{
+	{Key: "request_id", Value: requestid.FromContext},
+	{Key: "trace_id", Value: traceid.FromContext},
+	{Key: "principal", Value: principal.FromContext},
+}
@@ -1,7 +1,20 @@
 package logger
 
+import "context"
+
+// Logger writes structured log entries. fields are alternating key/value
+// pairs; values attached to ctx (request ID, trace ID, principal) are added
+// automatically by the implementation.
 type Logger interface {
-	Error(err error)
-	Warning(message string)
-	Info(message string)
+	Debug(ctx context.Context, message string, fields ...any)
+	Info(ctx context.Context, message string, fields ...any)
+	Warning(ctx context.Context, message string, fields ...any)
+	Error(ctx context.Context, err error, fields ...any)
+}
+
+// ContextField extracts a value from a context to be attached to every
+// entry logged with that context. Empty values are omitted.
+type ContextField struct {
+	Key   string
+	Value func(ctx context.Context) string
 }
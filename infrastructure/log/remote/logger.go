@@ -0,0 +1,303 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	logv1 "github.com/mahdimehrabi/m1-log-proto/gen/go/log/v1"
+	logger "m1-article-service/infrastructure/log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Backpressure policies applied when the buffer is full.
+const (
+	// PolicyDrop discards the new entry and counts it as dropped.
+	PolicyDrop = "drop"
+	// PolicyBlock makes the caller wait until there is room in the buffer
+	// or its context is done.
+	PolicyBlock = "block"
+)
+
+const (
+	levelDebug   = "debug"
+	levelInfo    = "info"
+	levelWarning = "warn"
+	levelError   = "error"
+
+	flushTimeout = 5 * time.Second
+)
+
+// levels orders the log levels; entries below the configured level are
+// discarded before they are buffered.
+var levels = map[string]int{levelDebug: 0, levelInfo: 1, levelWarning: 2, levelError: 3}
+
+type Config struct {
+	// Level is one of debug, info, warn or error; it defaults to info like
+	// the local logger.
+	Level         string
+	BatchSize     int
+	BufferSize    int
+	FlushInterval time.Duration
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	Policy        string
+}
+
+func (c Config) withDefaults() Config {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = 10000
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = time.Second
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 100 * time.Millisecond
+	}
+	if c.Policy == "" {
+		c.Policy = PolicyDrop
+	}
+	if _, ok := levels[c.Level]; !ok {
+		c.Level = levelInfo
+	}
+	return c
+}
+
+type entry struct {
+	time    time.Time
+	level   string
+	message string
+	err     error
+	fields  []any
+}
+
+// Logger ships log entries to the log service in batches. Entries are
+// buffered in memory and sent by Run; when the log service cannot be reached
+// after the configured retries, or once Run has returned, entries are written
+// to the fallback logger instead.
+type Logger struct {
+	client        logv1.LogServiceClient
+	fallback      logger.Logger
+	cfg           Config
+	contextFields []logger.ContextField
+
+	entries chan entry
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Int64
+}
+
+func NewLogger(client logv1.LogServiceClient, fallback logger.Logger, cfg Config, contextFields ...logger.ContextField) *Logger {
+	cfg = cfg.withDefaults()
+	return &Logger{
+		client:        client,
+		fallback:      fallback,
+		cfg:           cfg,
+		contextFields: contextFields,
+		entries:       make(chan entry, cfg.BufferSize),
+		done:          make(chan struct{}),
+	}
+}
+
+func (l *Logger) Debug(ctx context.Context, msg string, fields ...any) {
+	l.enqueue(ctx, entry{level: levelDebug, message: msg, fields: fields})
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, fields ...any) {
+	l.enqueue(ctx, entry{level: levelInfo, message: msg, fields: fields})
+}
+
+func (l *Logger) Warning(ctx context.Context, msg string, fields ...any) {
+	l.enqueue(ctx, entry{level: levelWarning, message: msg, fields: fields})
+}
+
+func (l *Logger) Error(ctx context.Context, err error, fields ...any) {
+	l.enqueue(ctx, entry{level: levelError, err: err, fields: fields})
+}
+
+// Dropped returns the number of entries discarded because the buffer was full.
+func (l *Logger) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+func (l *Logger) enqueue(ctx context.Context, e entry) {
+	if levels[e.level] < levels[l.cfg.Level] {
+		return
+	}
+	e.time = time.Now()
+	if ctx != nil {
+		for _, f := range l.contextFields {
+			if v := f.Value(ctx); v != "" {
+				e.fields = append(e.fields, f.Key, v)
+			}
+		}
+	}
+
+	select {
+	case <-l.done:
+		l.writeFallback(e)
+		return
+	default:
+	}
+
+	if l.cfg.Policy == PolicyBlock && ctx != nil {
+		select {
+		case l.entries <- e:
+		case <-l.done:
+			l.writeFallback(e)
+		case <-ctx.Done():
+			l.dropped.Add(1)
+		}
+		return
+	}
+
+	select {
+	case l.entries <- e:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Run sends buffered entries until ctx is done, then flushes what is left.
+func (l *Logger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]entry, 0, l.cfg.BatchSize)
+	var reportedDrops int64
+	for {
+		select {
+		case e := <-l.entries:
+			batch = append(batch, e)
+			if len(batch) >= l.cfg.BatchSize {
+				l.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			l.flush(batch)
+			batch = batch[:0]
+			if dropped := l.Dropped(); dropped > reportedDrops {
+				l.fallback.Warning(ctx, "log buffer full, entries dropped", "dropped", dropped-reportedDrops)
+				reportedDrops = dropped
+			}
+		case <-ctx.Done():
+			l.once.Do(func() { close(l.done) })
+		drain:
+			for {
+				select {
+				case e := <-l.entries:
+					batch = append(batch, e)
+				default:
+					break drain
+				}
+			}
+			l.flush(batch)
+			return ctx.Err()
+		}
+	}
+}
+
+// flush sends batch with retries. It is bounded by flushTimeout rather than
+// the Run context so that batches in flight are not lost on shutdown.
+func (l *Logger) flush(batch []entry) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	backoff := l.cfg.RetryBackoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = l.send(ctx, batch); err == nil {
+			return
+		}
+		if attempt >= l.cfg.MaxRetries || !sleep(ctx, backoff) {
+			break
+		}
+		backoff *= 2
+	}
+
+	l.fallback.Warning(context.Background(), "log service unavailable, writing entries locally",
+		"entries", len(batch), "error", err.Error())
+	for _, e := range batch {
+		l.writeFallback(e)
+	}
+}
+
+func (l *Logger) send(ctx context.Context, batch []entry) error {
+	stream, err := l.client.StoreLog(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range batch {
+		payload, err := e.marshal()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&logv1.Log{Error: string(payload)}); err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func (l *Logger) writeFallback(e entry) {
+	ctx := context.Background()
+	fields := append(e.fields, "time", e.time)
+	switch e.level {
+	case levelDebug:
+		l.fallback.Debug(ctx, e.message, fields...)
+	case levelInfo:
+		l.fallback.Info(ctx, e.message, fields...)
+	case levelWarning:
+		l.fallback.Warning(ctx, e.message, fields...)
+	default:
+		l.fallback.Error(ctx, e.err, fields...)
+	}
+}
+
+// marshal encodes the entry as a single JSON object; the log service only
+// has a free-form text field.
+func (e entry) marshal() ([]byte, error) {
+	obj := make(map[string]any, len(e.fields)/2+4)
+	for i := 0; i+1 < len(e.fields); i += 2 {
+		key, ok := e.fields[i].(string)
+		if !ok {
+			key = fmt.Sprint(e.fields[i])
+		}
+		value := e.fields[i+1]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		obj[key] = value
+	}
+	obj["time"] = e.time.Format(time.RFC3339Nano)
+	obj["level"] = e.level
+	if e.message != "" {
+		obj["message"] = e.message
+	}
+	if e.err != nil {
+		obj["error"] = e.err.Error()
+	}
+	return json.Marshal(obj)
+}
+
+// sleep waits for d and reports false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
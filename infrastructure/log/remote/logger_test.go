@@ -0,0 +1,162 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	logv1 "github.com/mahdimehrabi/m1-log-proto/gen/go/log/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/requestid"
+	infraMock "m1-article-service/mock/infrastructure"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLogServer is an in-process log service recording every received entry.
+type fakeLogServer struct {
+	logv1.UnimplementedLogServiceServer
+	mu          sync.Mutex
+	entries     []map[string]any
+	unavailable bool
+}
+
+func (s *fakeLogServer) StoreLog(stream logv1.LogService_StoreLogServer) error {
+	s.mu.Lock()
+	unavailable := s.unavailable
+	s.mu.Unlock()
+	if unavailable {
+		return status.Error(codes.Unavailable, "down")
+	}
+	for {
+		log, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&logv1.Empty{})
+		} else if err != nil {
+			return err
+		}
+		entry := map[string]any{}
+		if err := json.Unmarshal([]byte(log.Error), &entry); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.entries = append(s.entries, entry)
+		s.mu.Unlock()
+	}
+}
+
+func (s *fakeLogServer) received() []map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]map[string]any(nil), s.entries...)
+}
+
+func newClient(t *testing.T, srv *fakeLogServer) logv1.LogServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	logv1.RegisterLogServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return logv1.NewLogServiceClient(conn)
+}
+
+func TestLogger_Ships(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fallback := infraMock.NewMockLog(ctrl)
+	srv := &fakeLogServer{}
+	l := NewLogger(newClient(t, srv), fallback, Config{BatchSize: 2, FlushInterval: time.Hour},
+		logger.DefaultContextFields...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx)
+		close(done)
+	}()
+
+	reqCtx := requestid.NewContext(context.Background(), "req-1")
+	l.Info(reqCtx, "created", "article_id", 1)
+	l.Error(reqCtx, errors.New("boom"))
+	l.Warning(reqCtx, "flushed on shutdown")
+
+	cancel()
+	<-done
+
+	entries := srv.received()
+	if len(entries) != 3 {
+		t.Fatalf("received %d entries, want 3", len(entries))
+	}
+	if entries[0]["message"] != "created" || entries[0]["article_id"] != float64(1) || entries[0]["request_id"] != "req-1" {
+		t.Errorf("unexpected first entry %v", entries[0])
+	}
+	if entries[1]["level"] != "error" || entries[1]["error"] != "boom" {
+		t.Errorf("unexpected second entry %v", entries[1])
+	}
+}
+
+func TestLogger_Level(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	srv := &fakeLogServer{}
+	l := NewLogger(newClient(t, srv), infraMock.NewMockLog(ctrl), Config{Level: "warn", FlushInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx)
+		close(done)
+	}()
+
+	l.Debug(context.Background(), "debug")
+	l.Info(context.Background(), "info")
+	l.Warning(context.Background(), "warning")
+	l.Error(context.Background(), errors.New("boom"))
+
+	cancel()
+	<-done
+
+	entries := srv.received()
+	if len(entries) != 2 {
+		t.Fatalf("received %d entries, want 2: %v", len(entries), entries)
+	}
+	if entries[0]["level"] != "warn" || entries[1]["level"] != "error" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+}
+
+func TestLogger_Fallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fallback := infraMock.NewMockLog(ctrl)
+	fallback.EXPECT().Warning(gomock.Any(), "log service unavailable, writing entries locally", gomock.Any())
+	fallback.EXPECT().Info(gomock.Any(), "created", gomock.Any())
+
+	srv := &fakeLogServer{unavailable: true}
+	l := NewLogger(newClient(t, srv), fallback, Config{MaxRetries: 2, RetryBackoff: time.Millisecond})
+	l.flush([]entry{{level: levelInfo, message: "created", time: time.Now()}})
+}
+
+func TestLogger_DropWhenFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	l := NewLogger(nil, infraMock.NewMockLog(ctrl), Config{BufferSize: 1})
+	l.Info(context.Background(), "kept")
+	l.Info(context.Background(), "dropped")
+	if l.Dropped() != 1 {
+		t.Errorf("dropped = %d, want 1", l.Dropped())
+	}
+}
@@ -1,24 +1,87 @@
 package zerolog
 
-import "github.com/rs/zerolog/log"
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog"
+	"io"
+	logger "m1-article-service/infrastructure/log"
+	"os"
+	"time"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+type Config struct {
+	// Level is one of debug, info, warn or error.
+	Level string
+	// Format is either json or console.
+	Format string
+	// Output defaults to stdout.
+	Output io.Writer
+}
 
 type Logger struct {
+	logger        zerolog.Logger
+	contextFields []logger.ContextField
+}
+
+func NewLogger(cfg Config, contextFields ...logger.ContextField) (*Logger, error) {
+	level := zerolog.InfoLevel
+	if cfg.Level != "" {
+		var err error
+		if level, err = zerolog.ParseLevel(cfg.Level); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+	}
+
+	out := cfg.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	switch cfg.Format {
+	case "", FormatJSON:
+	case FormatConsole:
+		out = zerolog.ConsoleWriter{Out: out, TimeFormat: time.RFC3339}
+	default:
+		return nil, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
+
+	return &Logger{
+		logger:        zerolog.New(out).Level(level).With().Timestamp().Logger(),
+		contextFields: contextFields,
+	}, nil
+}
+
+func (l Logger) Debug(ctx context.Context, msg string, fields ...any) {
+	l.write(ctx, l.logger.Debug(), fields).Msg(msg)
 }
 
-func NewLogger() *Logger {
-	return &Logger{}
+func (l Logger) Info(ctx context.Context, msg string, fields ...any) {
+	l.write(ctx, l.logger.Info(), fields).Msg(msg)
 }
 
-func (l Logger) Error(err error) {
-	log.Err(err).Send()
+func (l Logger) Warning(ctx context.Context, msg string, fields ...any) {
+	l.write(ctx, l.logger.Warn(), fields).Msg(msg)
 }
 
-func (l Logger) Warning(msg string) {
-	warn := log.Warn()
-	warn.Msg(msg)
+func (l Logger) Error(ctx context.Context, err error, fields ...any) {
+	l.write(ctx, l.logger.Error().Err(err), fields).Send()
 }
 
-func (l Logger) Info(msg string) {
-	warn := log.Info()
-	warn.Msg(msg)
+func (l Logger) write(ctx context.Context, e *zerolog.Event, fields []any) *zerolog.Event {
+	if ctx != nil {
+		for _, f := range l.contextFields {
+			if v := f.Value(ctx); v != "" {
+				e = e.Str(f.Key, v)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		e = e.Fields(fields)
+	}
+	return e
 }
@@ -0,0 +1,49 @@
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	logger "m1-article-service/infrastructure/log"
+	"m1-article-service/infrastructure/requestid"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, err := NewLogger(Config{Level: "info", Output: buf}, logger.DefaultContextFields...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	l.Debug(ctx, "dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("debug entry should be filtered at info level, got %s", buf.String())
+	}
+
+	l.Error(ctx, errors.New("boom"), "article_id", 7)
+	entry := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("entry is not JSON: %v", err)
+	}
+	want := map[string]any{"level": "error", "error": "boom", "article_id": float64(7), "request_id": "req-1"}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("%s = %v, want %v", k, entry[k], v)
+		}
+	}
+	if _, ok := entry["principal"]; ok {
+		t.Error("empty context values should be omitted")
+	}
+}
+
+func TestNewLogger_InvalidConfig(t *testing.T) {
+	if _, err := NewLogger(Config{Level: "loud"}); err == nil {
+		t.Error("expected error for invalid level")
+	}
+	if _, err := NewLogger(Config{Format: "xml"}); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
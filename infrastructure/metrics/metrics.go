@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "article_service"
+
+// Metrics holds every collector exported by the service on its own registry.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	// RPCRequests counts finished RPCs by method and status code.
+	RPCRequests *prometheus.CounterVec
+	// RPCDuration observes RPC latency by method and status code.
+	RPCDuration *prometheus.HistogramVec
+	// QueryDuration observes repository latency by operation and result.
+	QueryDuration *prometheus.HistogramVec
+	// ArticleEvents counts successful article mutations by event.
+	ArticleEvents *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		RPCRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of finished gRPC requests.",
+		}, []string{"method", "code"}),
+		RPCDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		QueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "query_duration_seconds",
+			Help:      "Latency of article repository operations.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation", "result"}),
+		ArticleEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "articles_total",
+			Help:      "Number of article mutations by event (created, updated, published, deleted).",
+		}, []string{"event"}),
+	}
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.RPCRequests,
+		m.RPCDuration,
+		m.QueryDuration,
+		m.ArticleEvents,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+// NewServer returns an HTTP server exposing /metrics on addr.
+func (m *Metrics) NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
@@ -0,0 +1,137 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"io/fs"
+	"m1-article-service/domain/entity/migration"
+	"strings"
+	"time"
+)
+
+var (
+	ErrDirty       = errors.New("database schema is dirty")
+	ErrNewerSchema = errors.New("database schema is newer than this binary")
+)
+
+// Migrator applies the migrations embedded in the binary for the database
+// named by the URL scheme. On Postgres every operation holds an advisory
+// lock so concurrently starting replicas do not race each other.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// Status describes the schema of the database.
+type Status struct {
+	// Version is 0 when no migration has been applied.
+	Version uint
+	Dirty   bool
+	// Latest is the highest version embedded in the binary.
+	Latest uint
+}
+
+func New(databaseURL string, lockTimeout time.Duration) (*Migrator, error) {
+	var migrations fs.FS = migration.FS
+	if strings.HasPrefix(databaseURL, "sqlite://") {
+		migrations = migration.SQLiteFS
+	}
+	latest, err := migration.Latest(migrations)
+	if err != nil {
+		return nil, err
+	}
+	source, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", source, driverURL(databaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("opening migrations: %w", err)
+	}
+	if lockTimeout > 0 {
+		m.LockTimeout = lockTimeout
+	}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+// driverURL selects the pgx v5 driver for postgres URLs.
+func driverURL(databaseURL string) string {
+	for _, scheme := range []string{"postgres://", "postgresql://"} {
+		if strings.HasPrefix(databaseURL, scheme) {
+			return "pgx5://" + strings.TrimPrefix(databaseURL, scheme)
+		}
+	}
+	return databaseURL
+}
+
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down reverts the given number of migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("invalid number of steps %d", steps)
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(version uint) error {
+	if version > m.latest {
+		return fmt.Errorf("version %d does not exist, latest is %d", version, m.latest)
+	}
+	if version == 0 {
+		return ignoreNoChange(m.m.Down())
+	}
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Force sets the schema version without running any migration and clears
+// the dirty flag; it is used to recover after a failed migration was fixed
+// by hand.
+func (m *Migrator) Force(version uint) error {
+	return m.m.Force(int(version))
+}
+
+func (m *Migrator) Status() (Status, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return Status{Latest: m.latest}, nil
+	} else if err != nil {
+		return Status{}, err
+	}
+	return Status{Version: version, Dirty: dirty, Latest: m.latest}, nil
+}
+
+// Check returns ErrDirty or ErrNewerSchema if the service must not start on
+// the current schema.
+func (m *Migrator) Check() error {
+	s, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if s.Dirty {
+		return fmt.Errorf("%w: version %d", ErrDirty, s.Version)
+	}
+	if s.Version > s.Latest {
+		return fmt.Errorf("%w: version %d, latest known %d", ErrNewerSchema, s.Version, s.Latest)
+	}
+	return nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package principal
+
+import "context"
+
+// Header is the metadata key the API gateway uses to pass the authenticated
+// caller to this service. It is not verified, so it is only honoured from
+// the proxies presenting the shared secret (see TRUSTED_PROXY_SECRET).
+const Header = "x-principal"
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal stored in ctx or an empty string.
+func FromContext(ctx context.Context) string {
+	p, _ := ctx.Value(contextKey{}).(string)
+	return p
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	logger "m1-article-service/infrastructure/log"
+	"time"
+)
+
+// refilled is the number of tokens of a stored bucket before the request;
+// $2 is the rate and $3 the burst.
+const refilled = `least($3::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $2::float8)`
+
+// allowQuery takes a token from the bucket of $1 in a single statement, so
+// that concurrent requests of all replicas are counted. allowed records
+// whether the request took a token.
+var allowQuery = fmt.Sprintf(`INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, full_at)
+	VALUES ($1, $3::float8 - 1, true, now(), now() + make_interval(secs => 1 / $2::float8))
+	ON CONFLICT (key) DO UPDATE SET
+		allowed = %[1]s >= 1,
+		tokens = %[1]s - CASE WHEN %[1]s >= 1 THEN 1 ELSE 0 END,
+		updated_at = now(),
+		full_at = now() + make_interval(secs => ($3::float8 - %[1]s + CASE WHEN %[1]s >= 1 THEN 1 ELSE 0 END) / $2::float8)
+	RETURNING allowed, tokens`, refilled)
+
+// Postgres shares the buckets between the replicas of the service through
+// the rate_limit_buckets table. Run drops the buckets that refilled.
+type Postgres struct {
+	conn   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPostgres(conn *pgxpool.Pool, logger logger.Logger) *Postgres {
+	return &Postgres{conn: conn, logger: logger}
+}
+
+func (p *Postgres) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	var (
+		allowed bool
+		tokens  float64
+	)
+	if err := p.conn.QueryRow(ctx, allowQuery, key, limit.Rate, limit.Burst).Scan(&allowed, &tokens); err != nil {
+		return false, 0, err
+	}
+	if !allowed {
+		return false, seconds((1 - tokens) / limit.Rate), nil
+	}
+	return true, 0, nil
+}
+
+// Run drops the refilled buckets every sweep interval until ctx is done.
+func (p *Postgres) Run(ctx context.Context) error {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := p.conn.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE full_at <= now()`); err != nil && ctx.Err() == nil {
+				p.logger.Error(ctx, fmt.Errorf("pruning rate limit buckets: %w", err))
+			}
+		}
+	}
+}
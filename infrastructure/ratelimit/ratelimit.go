@@ -0,0 +1,104 @@
+// Package ratelimit limits the rate of the requests of every client with
+// token buckets, kept in memory or shared through Postgres.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst tokens and refilled with Rate
+// tokens per second; every request takes a token.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter keeps a bucket per key.
+type Limiter interface {
+	// Allow takes a token from the bucket of key. When the bucket is empty
+	// the request is denied and retryAfter is the time until the next
+	// token.
+	Allow(ctx context.Context, key string, limit Limit) (ok bool, retryAfter time.Duration, err error)
+}
+
+// Rule limits the requests of a client to the methods matching Pattern:
+// a full gRPC method name (/article.v1.ArticleService/Create), a method
+// name of any service (Create) or * for every other method. The methods
+// matching a pattern share the bucket of the client.
+type Rule struct {
+	Pattern string
+	Limit   Limit
+}
+
+// Rules are parsed from a comma separated list of
+// pattern=requests/period[:burst] items, for example
+// "Create=10/1m:20,*=100/1s"; the burst defaults to the number of requests.
+type Rules []Rule
+
+func ParseRules(s string) (Rules, error) {
+	var rules Rules
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		rule, err := parseRule(item)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit rule %q: %w", item, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRule(item string) (Rule, error) {
+	pattern, spec, ok := strings.Cut(item, "=")
+	if !ok || pattern == "" {
+		return Rule{}, fmt.Errorf("want pattern=requests/period[:burst]")
+	}
+	spec, burstSpec, hasBurst := strings.Cut(spec, ":")
+	requestsSpec, periodSpec, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("want pattern=requests/period[:burst]")
+	}
+	requests, err := strconv.Atoi(requestsSpec)
+	if err != nil || requests < 1 {
+		return Rule{}, fmt.Errorf("requests must be a positive integer")
+	}
+	period, err := time.ParseDuration(periodSpec)
+	if err != nil || period <= 0 {
+		return Rule{}, fmt.Errorf("period must be a positive duration such as 1s or 1m")
+	}
+	burst := requests
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstSpec); err != nil || burst < 1 {
+			return Rule{}, fmt.Errorf("burst must be a positive integer")
+		}
+	}
+	return Rule{Pattern: pattern, Limit: Limit{Rate: float64(requests) / period.Seconds(), Burst: burst}}, nil
+}
+
+// For returns the rule of a full gRPC method name, preferring full names
+// over method names over *.
+func (r Rules) For(fullMethod string) (Rule, bool) {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	var (
+		found Rule
+		rank  int
+	)
+	for _, rule := range r {
+		switch {
+		case rule.Pattern == fullMethod:
+			return rule, true
+		case rule.Pattern == name && rank < 2:
+			found, rank = rule, 2
+		case rule.Pattern == "*" && rank < 1:
+			found, rank = rule, 1
+		}
+	}
+	return found, rank > 0
+}
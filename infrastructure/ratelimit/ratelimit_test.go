@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"m1-article-service/infrastructure/migrator"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		spec  string
+		want  Rules
+		valid bool
+	}{
+		{"", nil, true},
+		{"Create=10/1m", Rules{{"Create", Limit{Rate: 10.0 / 60, Burst: 10}}}, true},
+		{" Create=10/1s:20 , *=100/1s ", Rules{
+			{"Create", Limit{Rate: 10, Burst: 20}},
+			{"*", Limit{Rate: 100, Burst: 100}},
+		}, true},
+		{"/article.v1.ArticleService/Delete=1/2s", Rules{{"/article.v1.ArticleService/Delete", Limit{Rate: 0.5, Burst: 1}}}, true},
+		{"Create", nil, false},
+		{"=1/1s", nil, false},
+		{"Create=10", nil, false},
+		{"Create=0/1s", nil, false},
+		{"Create=10/soon", nil, false},
+		{"Create=10/0s", nil, false},
+		{"Create=10/1s:0", nil, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseRules(tt.spec)
+		if tt.valid != (err == nil) {
+			t.Errorf("ParseRules(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseRules(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestRules_For(t *testing.T) {
+	rules, err := ParseRules("*=100/1s,Create=10/1s,/article.v1.ArticleService/Create=1/1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		method  string
+		pattern string
+	}{
+		{"/article.v1.ArticleService/Create", "/article.v1.ArticleService/Create"},
+		{"/article.v1.WebhookService/Create", "Create"},
+		{"/article.v1.ArticleService/List", "*"},
+	}
+	for _, tt := range tests {
+		if rule, ok := rules.For(tt.method); !ok || rule.Pattern != tt.pattern {
+			t.Errorf("For(%q) = %q, %v, want %q", tt.method, rule.Pattern, ok, tt.pattern)
+		}
+	}
+	if _, ok := (Rules{{"Create", Limit{1, 1}}}).For("/article.v1.ArticleService/List"); ok {
+		t.Error("a method without rule is limited")
+	}
+}
+
+func TestMemory_Allow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if ok, _, _ := m.Allow(ctx, "alice", limit); !ok {
+			t.Fatalf("request %d of the burst denied", i+1)
+		}
+	}
+	ok, retryAfter, _ := m.Allow(ctx, "alice", limit)
+	if ok || retryAfter != 500*time.Millisecond {
+		t.Fatalf("request over the burst = %v, retry after %v, want denied for 500ms", ok, retryAfter)
+	}
+	if ok, _, _ := m.Allow(ctx, "bob", limit); !ok {
+		t.Error("another key shares the bucket")
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	if ok, retryAfter, _ := m.Allow(ctx, "alice", limit); ok || retryAfter != 250*time.Millisecond {
+		t.Errorf("half refilled token = %v, retry after %v, want denied for 250ms", ok, retryAfter)
+	}
+	now = now.Add(250 * time.Millisecond)
+	if ok, _, _ := m.Allow(ctx, "alice", limit); !ok {
+		t.Error("refilled token denied")
+	}
+
+	now = now.Add(time.Hour)
+	m.Allow(ctx, "carol", limit)
+	if _, ok := m.buckets["alice"]; ok {
+		t.Error("refilled bucket was not dropped")
+	}
+}
+
+// TestPostgres_Allow runs against the database in TEST_DATABASE_URL.
+func TestPostgres_Allow(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	m, err := migrator.New(dsn, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+	pool, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	ctx := context.Background()
+	key := "test:" + t.Name() + time.Now().String()
+	// A rate this low does not refill a token during the test.
+	limit := Limit{Rate: 0.001, Burst: 2}
+	p := NewPostgres(pool, nil)
+	for i := 0; i < 2; i++ {
+		if ok, _, err := p.Allow(ctx, key, limit); err != nil || !ok {
+			t.Fatalf("request %d of the burst = %v, %v", i+1, ok, err)
+		}
+	}
+	ok, retryAfter, err := p.Allow(ctx, key, limit)
+	if err != nil || ok || retryAfter < 900*time.Second {
+		t.Errorf("request over the burst = %v, retry after %v, %v", ok, retryAfter, err)
+	}
+}
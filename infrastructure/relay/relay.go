@@ -0,0 +1,83 @@
+// Package relay delivers the events of the article outbox to a sink.
+package relay
+
+import (
+	"context"
+	"fmt"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article"
+	logger "m1-article-service/infrastructure/log"
+	"time"
+)
+
+type Config struct {
+	BatchSize    int
+	PollInterval time.Duration
+	// MaxBackoff bounds the delay between retries of an event the sink
+	// keeps failing.
+	MaxBackoff time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.MaxBackoff < c.PollInterval {
+		c.MaxBackoff = max(time.Minute, c.PollInterval)
+	}
+	return c
+}
+
+// Relay polls the outbox and hands every event to the sink, at least once
+// and in the order the events were recorded.
+type Relay struct {
+	outbox article.Outbox
+	sink   Sink
+	logger logger.Logger
+	cfg    Config
+}
+
+func New(outbox article.Outbox, sink Sink, logger logger.Logger, cfg Config) *Relay {
+	return &Relay{outbox: outbox, sink: sink, logger: logger, cfg: cfg.withDefaults()}
+}
+
+// Run delivers events until ctx is done. A full batch is followed by the
+// next one at once; an event the sink rejects is retried with exponential
+// backoff, holding back the events after it.
+func (r *Relay) Run(ctx context.Context) error {
+	var backoff time.Duration
+	for {
+		n, err := r.outbox.Dispatch(ctx, r.cfg.BatchSize, func(e *entity.Event) error {
+			if err := r.sink.Deliver(ctx, e); err != nil {
+				return fmt.Errorf("delivering event %d (%s of article %d): %w", e.ID, e.Type, e.ArticleID, err)
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		wait := r.cfg.PollInterval
+		switch {
+		case err != nil:
+			backoff = min(max(2*backoff, r.cfg.PollInterval), r.cfg.MaxBackoff)
+			wait = backoff
+			r.logger.Error(ctx, err, "delivered", n, "retry_in", wait)
+		case n == r.cfg.BatchSize:
+			backoff, wait = 0, 0
+		default:
+			backoff = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
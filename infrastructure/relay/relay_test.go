@@ -0,0 +1,110 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/article/memory"
+	infraMock "m1-article-service/mock/infrastructure"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a sink that fails the first failures deliveries and records
+// the IDs of the events it accepted.
+type recorder struct {
+	mu        sync.Mutex
+	failures  int
+	delivered []int64
+	done      chan struct{}
+	want      int
+}
+
+func (r *recorder) Deliver(_ context.Context, e *entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("sink down")
+	}
+	r.delivered = append(r.delivered, e.ID)
+	if len(r.delivered) == r.want {
+		close(r.done)
+	}
+	return nil
+}
+
+func runRelay(t *testing.T, sink *recorder, articles int, cfg Config) []int64 {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Times(sink.failures)
+
+	repo := memory.NewArticleRepository()
+	for i := 0; i < articles; i++ {
+		if _, err := repo.Create(context.Background(), &entity.Article{Title: string(rune('a' + i)), Status: entity.StatusPublished}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() { stopped <- New(repo, sink, loggerMock, cfg).Run(ctx) }()
+	select {
+	case <-sink.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("events were not delivered")
+	}
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Errorf("Run = %v after cancel", err)
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	return sink.delivered
+}
+
+func TestRelay_DeliversInOrder(t *testing.T) {
+	// Every article records a created and a published event; a batch size
+	// below the total makes the relay read several batches.
+	sink := &recorder{done: make(chan struct{}), want: 10}
+	got := runRelay(t, sink, 5, Config{BatchSize: 3, PollInterval: time.Hour})
+	want := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestRelay_RetriesFailedEvent(t *testing.T) {
+	sink := &recorder{failures: 2, done: make(chan struct{}), want: 4}
+	got := runRelay(t, sink, 2, Config{PollInterval: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+	want := []int64{1, 2, 3, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := Fanout(NewWriterSink(&buf), Discard)
+	for id := int64(1); id <= 2; id++ {
+		e := &entity.Event{ID: id, Type: entity.EventArticleCreated, ArticleID: 7, Article: &entity.Article{ID: 7}}
+		if err := sink.Deliver(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dec := json.NewDecoder(&buf)
+	for id := int64(1); id <= 2; id++ {
+		var e entity.Event
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		if e.ID != id || e.Type != entity.EventArticleCreated || e.Article.ID != 7 {
+			t.Errorf("line %d = %+v", id, e)
+		}
+	}
+}
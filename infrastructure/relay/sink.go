@@ -0,0 +1,68 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"m1-article-service/domain/entity"
+	logger "m1-article-service/infrastructure/log"
+	"sync"
+)
+
+// Sink receives the events of the outbox. An event may be delivered more
+// than once, e.g. when the process stops before the outbox learns it was
+// delivered, so consumers should deduplicate by event ID.
+type Sink interface {
+	Deliver(ctx context.Context, e *entity.Event) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, e *entity.Event) error
+
+func (f SinkFunc) Deliver(ctx context.Context, e *entity.Event) error {
+	return f(ctx, e)
+}
+
+// Discard accepts and drops every event, keeping the outbox empty when no
+// one consumes it.
+var Discard Sink = SinkFunc(func(context.Context, *entity.Event) error { return nil })
+
+// NewLogSink logs every event at info level.
+func NewLogSink(l logger.Logger) Sink {
+	return SinkFunc(func(ctx context.Context, e *entity.Event) error {
+		l.Info(ctx, "article event", "event_id", e.ID, "type", e.Type, "article_id", e.ArticleID)
+		return nil
+	})
+}
+
+// WriterSink writes every event as a line of JSON.
+type WriterSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{enc: json.NewEncoder(w)}
+}
+
+func (s *WriterSink) Deliver(_ context.Context, e *entity.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(e)
+}
+
+// Fanout delivers every event to all sinks. When one of them fails the
+// event is delivered to all of them again, so the sinks see the same
+// sequence of events.
+func Fanout(sinks ...Sink) Sink {
+	return SinkFunc(func(ctx context.Context, e *entity.Event) error {
+		var errs []error
+		for _, sink := range sinks {
+			if err := sink.Deliver(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
@@ -0,0 +1,16 @@
+package remoteaddr
+
+import "context"
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, contextKey{}, addr)
+}
+
+// FromContext returns the network address of the client stored in ctx or an
+// empty string.
+func FromContext(ctx context.Context) string {
+	addr, _ := ctx.Value(contextKey{}).(string)
+	return addr
+}
@@ -0,0 +1,15 @@
+package traceid
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromContext returns the ID of the trace the context's span belongs to, or
+// an empty string when the context carries no span.
+func FromContext(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
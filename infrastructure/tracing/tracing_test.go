@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"m1-article-service/infrastructure/traceid"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewProvider_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	tp, err := NewProvider(context.Background(), Config{
+		ServiceName: "test",
+		Exporter:    ExporterFile,
+		FilePath:    path,
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "operation")
+	if traceid.FromContext(ctx) != span.SpanContext().TraceID().String() {
+		t.Error("trace ID should be available from the context")
+	}
+	span.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Name":"operation"`) {
+		t.Errorf("span was not exported: %s", data)
+	}
+}
+
+func TestNewProvider_UnknownExporter(t *testing.T) {
+	if _, err := NewProvider(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Error("expected error for unknown exporter")
+	}
+}
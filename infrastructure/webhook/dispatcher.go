@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	logger "m1-article-service/infrastructure/log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	BatchSize    int
+	PollInterval time.Duration
+	// Concurrency is the number of requests sent at once.
+	Concurrency int
+	// Timeout bounds every request.
+	Timeout time.Duration
+	// MaxAttempts is the number of failed attempts after which a delivery
+	// is dead-lettered.
+	MaxAttempts int
+	// Backoff is the delay after the first failed attempt, doubled after
+	// every further one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// AllowPrivate lets webhooks target loopback, private and link-local
+	// addresses, for local setups and tests.
+	AllowPrivate bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = 4
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = 30 * time.Second
+	}
+	if c.MaxBackoff < c.Backoff {
+		c.MaxBackoff = max(time.Hour, c.Backoff)
+	}
+	return c
+}
+
+// Dispatcher enqueues a delivery of every outbox event for the webhooks
+// subscribed to it, as a relay.Sink, and sends the due deliveries.
+type Dispatcher struct {
+	repo   webhook.Webhook
+	client *http.Client
+	logger logger.Logger
+	cfg    Config
+}
+
+func NewDispatcher(repo webhook.Webhook, logger logger.Logger, cfg Config) *Dispatcher {
+	cfg = cfg.withDefaults()
+	return &Dispatcher{
+		repo:   repo,
+		client: newClient(cfg.Timeout, cfg.AllowPrivate),
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Deliver enqueues the deliveries of e; they are sent by Run.
+func (d *Dispatcher) Deliver(ctx context.Context, e *entity.Event) error {
+	return d.repo.Enqueue(ctx, e)
+}
+
+// Run sends the due deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	for {
+		n, err := d.dispatchDue(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			d.logger.Error(ctx, fmt.Errorf("claiming webhook deliveries: %w", err))
+		}
+		if n == d.cfg.BatchSize {
+			continue
+		}
+
+		timer := time.NewTimer(d.cfg.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatchDue claims a batch of due deliveries and attempts them, returning
+// how many there were.
+func (d *Dispatcher) dispatchDue(ctx context.Context) (int, error) {
+	// The lease covers the worst case of every request of the batch
+	// timing out.
+	rounds := (d.cfg.BatchSize + d.cfg.Concurrency - 1) / d.cfg.Concurrency
+	lease := time.Duration(rounds+1) * d.cfg.Timeout
+	deliveries, err := d.repo.Claim(ctx, time.Now(), lease, d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.cfg.Concurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(delivery *entity.WebhookDelivery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			d.attempt(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	w, err := d.repo.Get(ctx, delivery.WebhookID)
+	if errors.Is(err, webhook.ErrNotFound) {
+		// deleted with its deliveries since the claim
+		return
+	} else if err != nil {
+		d.logger.Error(ctx, err)
+		return
+	}
+
+	attempt := d.send(ctx, w, delivery)
+	if ctx.Err() != nil {
+		// Interrupted by the shutdown: the delivery is attempted again
+		// once its lease expires.
+		return
+	}
+	delivery.Attempts++
+	delivery.LastError = attempt.Error
+	switch {
+	case attempt.Error == "":
+		delivery.Status = entity.DeliveryDelivered
+		delivery.DeliveredAt = attempt.AttemptedAt
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = entity.DeliveryDead
+		d.logger.Warning(ctx, "webhook delivery dead-lettered", "delivery_id", delivery.ID, "webhook_id", w.ID,
+			"attempts", delivery.Attempts, "error", attempt.Error)
+	default:
+		delivery.NextAttemptAt = attempt.AttemptedAt.Add(d.backoff(delivery.Attempts))
+	}
+	if err := d.repo.RecordAttempt(ctx, delivery, attempt); err != nil {
+		d.logger.Error(ctx, fmt.Errorf("recording attempt of webhook delivery %d: %w", delivery.ID, err))
+	}
+}
+
+// send posts the payload of a delivery; the attempt has an Error unless
+// the response status is 2xx.
+func (d *Dispatcher) send(ctx context.Context, w *entity.Webhook, delivery *entity.WebhookDelivery) (attempt entity.WebhookAttempt) {
+	attempt = entity.WebhookAttempt{DeliveryID: delivery.ID, AttemptedAt: time.Now()}
+	defer func() { attempt.Duration = time.Since(attempt.AttemptedAt) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := attempt.AttemptedAt.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, delivery.Payload))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	// Draining a bounded part of the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	res.Body.Close()
+	attempt.StatusCode = res.StatusCode
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		attempt.Error = "unexpected status " + res.Status
+	}
+	return attempt
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.Backoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
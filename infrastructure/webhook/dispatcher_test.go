@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"io"
+	"m1-article-service/domain/entity"
+	"m1-article-service/domain/repository/webhook"
+	"m1-article-service/domain/repository/webhook/memory"
+	infraMock "m1-article-service/mock/infrastructure"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSign_Verify(t *testing.T) {
+	body := []byte(`{"type":"article.created"}`)
+	signature := Sign("secret", 1700000000, body)
+	if !Verify("secret", signature, 1700000000, body) {
+		t.Error("signature does not verify")
+	}
+	if Verify("other", signature, 1700000000, body) {
+		t.Error("signature verifies with another secret")
+	}
+	if Verify("secret", signature, 1700000001, body) {
+		t.Error("signature verifies with another timestamp")
+	}
+}
+
+func TestDispatcher_Delivers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if !Verify("secret", r.Header.Get(SignatureHeader), timestamp, body) {
+			t.Error("invalid signature")
+		}
+		if got := r.Header.Get(EventHeader); got != entity.EventArticleCreated {
+			t.Errorf("%s = %q", EventHeader, got)
+		}
+		var e entity.Event
+		if err := json.Unmarshal(body, &e); err != nil || e.ArticleID != 7 {
+			t.Errorf("body %s: %v", body, err)
+		}
+		received.Add(1)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := memory.NewWebhookRepository()
+	created, _ := repo.Create(ctx, &entity.Webhook{URL: server.URL, Secret: "secret", Events: []string{entity.EventArticleCreated}})
+	// subscribed to every event type, the deliveries of a deleted webhook
+	// are not sent
+	deleted, _ := repo.Create(ctx, &entity.Webhook{URL: server.URL, Secret: "secret"})
+
+	d := NewDispatcher(repo, loggerMock, Config{AllowPrivate: true})
+	for _, e := range []*entity.Event{
+		{ID: 1, Type: entity.EventArticleCreated, ArticleID: 7},
+		{ID: 2, Type: entity.EventArticleDeleted, ArticleID: 7},
+		// delivered again by the relay after a failure
+		{ID: 1, Type: entity.EventArticleCreated, ArticleID: 7},
+	} {
+		if err := d.Deliver(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.Delete(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := d.dispatchDue(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("dispatchDue = %d, %v, want 1 delivery", n, err)
+	}
+	if got := received.Load(); got != 1 {
+		t.Errorf("received %d requests, want 1", got)
+	}
+	deliveries, _ := repo.Deliveries(ctx, webhook.DeliveryFilter{WebhookID: created}, 1)
+	if len(deliveries) != 1 || deliveries[0].Status != entity.DeliveryDelivered || deliveries[0].Attempts != 1 {
+		t.Errorf("deliveries = %+v", deliveries)
+	}
+}
+
+func TestDispatcher_RetriesUntilDead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loggerMock := infraMock.NewMockLog(ctrl)
+	loggerMock.EXPECT().Warning(gomock.Any(), "webhook delivery dead-lettered", gomock.Any()).Times(1)
+
+	var failing atomic.Bool
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	repo := memory.NewWebhookRepository()
+	id, _ := repo.Create(ctx, &entity.Webhook{URL: server.URL, Secret: "secret"})
+	_ = repo.Enqueue(ctx, &entity.Event{ID: 1, Type: entity.EventArticleCreated})
+
+	// A backoff shorter than the clock resolution makes every retry due
+	// at once.
+	d := NewDispatcher(repo, loggerMock, Config{MaxAttempts: 3, Backoff: time.Nanosecond, MaxBackoff: time.Nanosecond, AllowPrivate: true})
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+		if n, err := d.dispatchDue(ctx); err != nil || n != 1 {
+			t.Fatalf("attempt %d: dispatchDue = %d, %v", i+1, n, err)
+		}
+	}
+	if n, _ := d.dispatchDue(ctx); n != 0 {
+		t.Fatalf("dead delivery attempted again")
+	}
+
+	delivery, _ := repo.Delivery(ctx, 1)
+	if delivery.Status != entity.DeliveryDead || delivery.Attempts != 3 || delivery.LastError == "" {
+		t.Errorf("delivery = %+v", delivery)
+	}
+	attempts, _ := repo.Attempts(ctx, 1)
+	if len(attempts) != 3 {
+		t.Fatalf("%d attempts logged, want 3", len(attempts))
+	}
+	for _, a := range attempts {
+		if a.StatusCode != http.StatusServiceUnavailable || a.Error == "" {
+			t.Errorf("attempt = %+v", a)
+		}
+	}
+
+	failing.Store(false)
+	if n, err := repo.Replay(ctx, id, nil); err != nil || n != 1 {
+		t.Fatalf("Replay = %d, %v", n, err)
+	}
+	if n, err := d.dispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("dispatchDue after replay = %d, %v", n, err)
+	}
+	delivery, _ = repo.Delivery(ctx, 1)
+	if delivery.Status != entity.DeliveryDelivered || delivery.DeliveredAt.IsZero() {
+		t.Errorf("replayed delivery = %+v", delivery)
+	}
+}
+
+func TestDispatcher_RefusesInternalTargets(t *testing.T) {
+	var received atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer redirect.Close()
+
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantError    string
+	}{
+		{"loopback address", target.URL, false, ErrNotPublic.Error()},
+		{"redirect", redirect.URL, true, "unexpected status 302 Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewWebhookRepository()
+			_, _ = repo.Create(ctx, &entity.Webhook{URL: tt.url, Secret: "secret"})
+			_ = repo.Enqueue(ctx, &entity.Event{ID: 1, Type: entity.EventArticleCreated})
+
+			d := NewDispatcher(repo, infraMock.NewMockLog(gomock.NewController(t)), Config{AllowPrivate: tt.allowPrivate})
+			if n, err := d.dispatchDue(ctx); err != nil || n != 1 {
+				t.Fatalf("dispatchDue = %d, %v", n, err)
+			}
+			delivery, _ := repo.Delivery(ctx, 1)
+			if delivery.Status != entity.DeliveryPending || !strings.Contains(delivery.LastError, tt.wantError) {
+				t.Errorf("delivery = %+v, want a failed attempt with %q", delivery, tt.wantError)
+			}
+			if got := received.Load(); got != 0 {
+				t.Errorf("target received %d requests, want none", got)
+			}
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"fd00::1":          false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"0.0.0.0":          false,
+		"::":               false,
+		"100.64.0.1":       false,
+		"224.0.0.1":        false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if got := isPublic(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublic(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(nil, nil, Config{Backoff: time.Second, MaxBackoff: 10 * time.Second})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{60, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
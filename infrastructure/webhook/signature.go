@@ -0,0 +1,36 @@
+// Package webhook sends the deliveries of webhook subscriptions.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of a delivery request.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader is the Unix time of the attempt, in seconds.
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	// DeliveryHeader identifies the delivery; it is the same for every
+	// attempt so that receivers can drop duplicates.
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Sign returns the signature of a request: "sha256=" followed by the hex
+// HMAC-SHA256, keyed with the webhook secret, of the timestamp, a dot and
+// the body. Covering the timestamp lets receivers reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of the request.
+func Verify(secret, signature string, timestamp int64, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
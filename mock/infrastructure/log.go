@@ -1,16 +1,17 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./infrastructure/article/article.go
+// Source: ./infrastructure/log/log.go
 
 // Package mock_log is a generated GoMock package.
 package mock_log
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockLog is a mock of Log interface.
+// MockLog is a mock of Logger interface.
 type MockLog struct {
 	ctrl     *gomock.Controller
 	recorder *MockLogMockRecorder
@@ -33,38 +34,70 @@ func (m *MockLog) EXPECT() *MockLogMockRecorder {
 	return m.recorder
 }
 
+// Debug mocks base method.
+func (m *MockLog) Debug(ctx context.Context, message string, fields ...any) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debug", varargs...)
+}
+
+// Debug indicates an expected call of Debug.
+func (mr *MockLogMockRecorder) Debug(ctx, message interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockLog)(nil).Debug), varargs...)
+}
+
 // Error mocks base method.
-func (m *MockLog) Error(err error) {
+func (m *MockLog) Error(ctx context.Context, err error, fields ...any) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", err)
+	varargs := []interface{}{ctx, err}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockLogMockRecorder) Error(err interface{}) *gomock.Call {
+func (mr *MockLogMockRecorder) Error(ctx, err interface{}, fields ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLog)(nil).Error), err)
+	varargs := append([]interface{}{ctx, err}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLog)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *MockLog) Info(message string) {
+func (m *MockLog) Info(ctx context.Context, message string, fields ...any) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", message)
+	varargs := []interface{}{ctx, message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockLogMockRecorder) Info(message interface{}) *gomock.Call {
+func (mr *MockLogMockRecorder) Info(ctx, message interface{}, fields ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLog)(nil).Info), message)
+	varargs := append([]interface{}{ctx, message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLog)(nil).Info), varargs...)
 }
 
 // Warning mocks base method.
-func (m *MockLog) Warning(message string) {
+func (m *MockLog) Warning(ctx context.Context, message string, fields ...any) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Warning", message)
+	varargs := []interface{}{ctx, message}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warning", varargs...)
 }
 
 // Warning indicates an expected call of Warning.
-func (mr *MockLogMockRecorder) Warning(message interface{}) *gomock.Call {
+func (mr *MockLogMockRecorder) Warning(ctx, message interface{}, fields ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warning", reflect.TypeOf((*MockLog)(nil).Warning), message)
+	varargs := append([]interface{}{ctx, message}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warning", reflect.TypeOf((*MockLog)(nil).Warning), varargs...)
 }
@@ -7,7 +7,9 @@ package mock_article
 import (
 	context "context"
 	entity "m1-article-service/domain/entity"
+	article "m1-article-service/domain/repository/article"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +37,51 @@ func (m *MockArticle) EXPECT() *MockArticleMockRecorder {
 	return m.recorder
 }
 
+// BatchCreate mocks base method.
+func (m *MockArticle) BatchCreate(arg0 context.Context, arg1 []*entity.Article) ([]article.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", arg0, arg1)
+	ret0, _ := ret[0].([]article.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockArticleMockRecorder) BatchCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockArticle)(nil).BatchCreate), arg0, arg1)
+}
+
+// BatchDelete mocks base method.
+func (m *MockArticle) BatchDelete(arg0 context.Context, arg1 []int64) ([]article.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", arg0, arg1)
+	ret0, _ := ret[0].([]article.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockArticleMockRecorder) BatchDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockArticle)(nil).BatchDelete), arg0, arg1)
+}
+
+// BatchDetail mocks base method.
+func (m *MockArticle) BatchDetail(arg0 context.Context, arg1 []int64) ([]*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDetail", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDetail indicates an expected call of BatchDetail.
+func (mr *MockArticleMockRecorder) BatchDetail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDetail", reflect.TypeOf((*MockArticle)(nil).BatchDetail), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockArticle) Create(arg0 context.Context, arg1 *entity.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +126,35 @@ func (mr *MockArticleMockRecorder) Detail(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detail", reflect.TypeOf((*MockArticle)(nil).Detail), arg0, arg1)
 }
 
+// Export mocks base method.
+func (m *MockArticle) Export(arg0 context.Context, arg1 article.ExportFilter, arg2 func(*entity.Article) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArticleMockRecorder) Export(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArticle)(nil).Export), arg0, arg1, arg2)
+}
+
+// Import mocks base method.
+func (m *MockArticle) Import(arg0 context.Context, arg1 []*entity.Article, arg2 article.ImportOptions) ([]article.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]article.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockArticleMockRecorder) Import(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockArticle)(nil).Import), arg0, arg1, arg2)
+}
+
 // List mocks base method.
 func (m *MockArticle) List(arg0 context.Context, arg1 uint16) ([]*entity.Article, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +170,21 @@ func (mr *MockArticleMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticle)(nil).List), arg0, arg1)
 }
 
+// Search mocks base method.
+func (m *MockArticle) Search(arg0 context.Context, arg1 string, arg2 uint16) ([]*entity.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockArticleMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockArticle)(nil).Search), arg0, arg1, arg2)
+}
+
 // Update mocks base method.
 func (m *MockArticle) Update(arg0 context.Context, arg1 *entity.Article) error {
 	m.ctrl.T.Helper()
@@ -107,3 +198,107 @@ func (mr *MockArticleMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticle)(nil).Update), arg0, arg1)
 }
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockOutbox) Dispatch(ctx context.Context, limit int, fn func(*entity.Event) error) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, limit, fn)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockOutboxMockRecorder) Dispatch(ctx, limit, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockOutbox)(nil).Dispatch), ctx, limit, fn)
+}
+
+// MockChanges is a mock of Changes interface.
+type MockChanges struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangesMockRecorder
+}
+
+// MockChangesMockRecorder is the mock recorder for MockChanges.
+type MockChangesMockRecorder struct {
+	mock *MockChanges
+}
+
+// NewMockChanges creates a new mock instance.
+func NewMockChanges(ctrl *gomock.Controller) *MockChanges {
+	mock := &MockChanges{ctrl: ctrl}
+	mock.recorder = &MockChangesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChanges) EXPECT() *MockChangesMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockChanges) Listen(ctx context.Context, ready func(), fn func(*entity.Event)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, ready, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockChangesMockRecorder) Listen(ctx, ready, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockChanges)(nil).Listen), ctx, ready, fn)
+}
+
+// Prune mocks base method.
+func (m *MockChanges) Prune(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockChangesMockRecorder) Prune(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockChanges)(nil).Prune), ctx, before)
+}
+
+// Since mocks base method.
+func (m *MockChanges) Since(ctx context.Context, after int64, limit int) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Since", ctx, after, limit)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Since indicates an expected call of Since.
+func (mr *MockChangesMockRecorder) Since(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Since", reflect.TypeOf((*MockChanges)(nil).Since), ctx, after, limit)
+}